@@ -0,0 +1,179 @@
+package app
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// ArchiveManifestName The name of the manifest in the archive, the first entry of the archive.
+const ArchiveManifestName = "manifest.json"
+
+// ArchiveFile A file of the archive as listed in the manifest.
+type ArchiveFile struct {
+	Name    string `json:"name"`            // slash separated path in the archive, the output path of the job
+	Size    int    `json:"size"`            // bytes of the content
+	Sha256  string `json:"sha256"`          // hex encoded SHA-256 of the content
+	Command string `json:"command"`         // command that rendered the file, such as table
+	Group   string `json:"group,omitempty"` // table group of the job, empty if the job is not grouped
+}
+
+// ArchiveManifest The manifest of the archive, written as manifest.json.
+type ArchiveManifest struct {
+	Version   string         `json:"version"`    // pts version
+	CreatedAt time.Time      `json:"created_at"` // creation time of the archive
+	Files     []*ArchiveFile `json:"files"`      // files in the order they were rendered
+}
+
+// Archive The rendered files bundled into a single archive instead of being written to the file system.
+type Archive struct {
+	name     string
+	manifest *ArchiveManifest
+	contents map[string][]byte
+}
+
+// NewArchive The archive written to the file: .tar.gz, .tgz, .tar or .zip.
+func NewArchive(name string) (*Archive, error) {
+	if archiveFormat(name) == "" {
+		return nil, fmt.Errorf("archive: unsupported archive %s, supported extensions: .tar.gz, .tgz, .tar, .zip", name)
+	}
+	return &Archive{
+		name:     name,
+		manifest: &ArchiveManifest{Version: Version(), Files: make([]*ArchiveFile, 0)},
+		contents: make(map[string][]byte),
+	}, nil
+}
+
+// archiveFormat The format of the archive by the file extension, empty if the extension is not supported.
+func archiveFormat(name string) string {
+	name = strings.ToLower(name)
+	for _, format := range []string{".tar.gz", ".tgz", ".tar", ".zip"} {
+		if strings.HasSuffix(name, format) {
+			return format
+		}
+	}
+	return ""
+}
+
+// archivePath The slash separated path of the output in the archive, ./table/table.go => table/table.go; the leading / and ../ are removed.
+func archivePath(output string) string {
+	name := path.Clean("/" + filepath.ToSlash(output))
+	return strings.TrimPrefix(name, "/")
+}
+
+// Add Add the rendered file, a file of the same path replaces the previous content.
+func (s *Archive) Add(output string, content []byte, command string, group string) {
+	name := archivePath(output)
+	sum := sha256.Sum256(content)
+	file := &ArchiveFile{
+		Name:    name,
+		Size:    len(content),
+		Sha256:  hex.EncodeToString(sum[:]),
+		Command: command,
+		Group:   group,
+	}
+	if _, ok := s.contents[name]; ok {
+		for i, v := range s.manifest.Files {
+			if v.Name == name {
+				s.manifest.Files[i] = file
+			}
+		}
+	} else {
+		s.manifest.Files = append(s.manifest.Files, file)
+	}
+	s.contents[name] = content
+}
+
+// Write Write the manifest and the files to the archive file, the file is replaced if it exists.
+func (s *Archive) Write() (err error) {
+	s.manifest.CreatedAt = time.Now().UTC().Truncate(time.Second)
+	manifest, err := json.MarshalIndent(s.manifest, "", "\t")
+	if err != nil {
+		return err
+	}
+	manifest = append(manifest, '\n')
+	buf := bytes.NewBuffer(nil)
+	switch archiveFormat(s.name) {
+	case ".zip":
+		err = s.writeZip(buf, manifest)
+	case ".tar":
+		err = s.writeTar(buf, manifest)
+	default:
+		compress := gzip.NewWriter(buf)
+		if err = s.writeTar(compress, manifest); err != nil {
+			return err
+		}
+		err = compress.Close()
+	}
+	if err != nil {
+		return err
+	}
+	if dir := filepath.Dir(s.name); dir != "." {
+		if err = os.MkdirAll(dir, 0o755); err != nil {
+			return err
+		}
+	}
+	return os.WriteFile(s.name, buf.Bytes(), 0o644)
+}
+
+func (s *Archive) writeTar(w io.Writer, manifest []byte) error {
+	writer := tar.NewWriter(w)
+	write := func(name string, content []byte) error {
+		header := &tar.Header{
+			Name:    name,
+			Mode:    0o644,
+			Size:    int64(len(content)),
+			ModTime: s.manifest.CreatedAt,
+		}
+		if err := writer.WriteHeader(header); err != nil {
+			return err
+		}
+		_, err := writer.Write(content)
+		return err
+	}
+	if err := write(ArchiveManifestName, manifest); err != nil {
+		return err
+	}
+	for _, file := range s.manifest.Files {
+		if err := write(file.Name, s.contents[file.Name]); err != nil {
+			return err
+		}
+	}
+	return writer.Close()
+}
+
+func (s *Archive) writeZip(w io.Writer, manifest []byte) error {
+	writer := zip.NewWriter(w)
+	write := func(name string, content []byte) error {
+		entry, err := writer.CreateHeader(&zip.FileHeader{
+			Name:     name,
+			Method:   zip.Deflate,
+			Modified: s.manifest.CreatedAt,
+		})
+		if err != nil {
+			return err
+		}
+		_, err = entry.Write(content)
+		return err
+	}
+	if err := write(ArchiveManifestName, manifest); err != nil {
+		return err
+	}
+	for _, file := range s.manifest.Files {
+		if err := write(file.Name, s.contents[file.Name]); err != nil {
+			return err
+		}
+	}
+	return writer.Close()
+}
@@ -0,0 +1,222 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/cd365/hey/v7/cst"
+)
+
+// atlasTypeIdentifier Column types written as Atlas type identifiers, such as bigint, varchar(64), decimal(10,2); the other types are written as sql("...").
+var atlasTypeIdentifier = regexp.MustCompile(`^[a-z_][a-z0-9_]*(\(\d+(,\d+)?\))?$`)
+
+// atlasIdentifier Names referenced without quotes in HCL, such as column.user_id.
+var atlasIdentifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*$`)
+
+// atlasNumber Numeric literal of a MySQL column default.
+var atlasNumber = regexp.MustCompile(`^-?\d+(\.\d+)?$`)
+
+// atlasString The HCL quoted string, the template sequences ${ and %{ are escaped.
+func atlasString(value string) string {
+	value = strconv.Quote(value)
+	value = strings.ReplaceAll(value, "${", "$${")
+	return strings.ReplaceAll(value, "%{", "%%{")
+}
+
+// atlasReference The reference of the column in the table block, column.id; column["order-id"] if the name is not an identifier.
+func atlasReference(kind string, name string) string {
+	if atlasIdentifier.MatchString(name) {
+		return kind + "." + name
+	}
+	return fmt.Sprintf("%s[%s]", kind, atlasString(name))
+}
+
+// atlasColumnType The Atlas type of the column and whether it is a MySQL unsigned integer.
+// The MySQL column type and the SQLite declared type are kept, the PostgreSQL type is the DDL type of the data type.
+func atlasColumnType(dialect string, column *Column) (string, bool, error) {
+	columnType, err := sqlType(dialect, column)
+	if err != nil {
+		return "", false, err
+	}
+	if (dialect == string(cst.Mysql) || dialect == string(cst.Sqlite)) && column.Type != nil && *column.Type != "" {
+		columnType = *column.Type
+	}
+	columnType = strings.ToLower(strings.TrimSpace(columnType))
+	unsigned := false
+	if dialect == string(cst.Mysql) {
+		if before, after, ok := strings.Cut(columnType, " unsigned"); ok {
+			columnType, unsigned = strings.TrimSpace(before+after), true
+		}
+	}
+	if atlasTypeIdentifier.MatchString(columnType) {
+		return columnType, unsigned, nil
+	}
+	return fmt.Sprintf("sql(%s)", atlasString(columnType)), unsigned, nil
+}
+
+// atlasDefault The Atlas default value of the column, empty if the column has no default or the default is the sequence of the auto-increment column.
+// MySQL column defaults are literals unless the extra is DEFAULT_GENERATED, the PostgreSQL and SQLite column defaults are expressions.
+func atlasDefault(dialect string, table *Table, column *Column) string {
+	if column.ColumnDefault == nil || column.Column == table.AutoIncrementColumn {
+		return ""
+	}
+	value := *column.ColumnDefault
+	if dialect != string(cst.Mysql) {
+		return fmt.Sprintf("sql(%s)", atlasString(value))
+	}
+	extra := ""
+	if column.Extra != nil {
+		extra = strings.ToUpper(*column.Extra)
+	}
+	switch {
+	case strings.Contains(extra, "DEFAULT_GENERATED"), strings.HasPrefix(strings.ToUpper(value), "CURRENT_TIMESTAMP"):
+		return fmt.Sprintf("sql(%s)", atlasString(value))
+	case atlasNumber.MatchString(value):
+		return value
+	}
+	return atlasString(value)
+}
+
+// atlasAction The Atlas referential action, SET NULL => SET_NULL; empty if the action is not known.
+func atlasAction(action string) string {
+	return strings.ReplaceAll(strings.ToUpper(strings.TrimSpace(action)), " ", "_")
+}
+
+// atlasHCL Atlas HCL schema of the exported tables: PostgreSQL, MySQL and SQLite; CockroachDB and Greenplum are written as PostgreSQL.
+// Foreign keys referencing tables that are not exported are omitted, SQLite foreign keys without referenced columns reference the primary key.
+// Generated column expressions and check constraints are not read from the database.
+func atlasHCL(tmp *Template) ([]byte, error) {
+	dialect := migrationDialect(tmp.Dialect)
+	exported := make(map[string]*Table, len(tmp.Tables))
+	schemas := make([]string, 0, 1)
+	schemaName := func(table *Table) string {
+		if table.Database == "" {
+			// The main database of SQLite
+			return "main"
+		}
+		return table.Database
+	}
+	for _, table := range tmp.Tables {
+		exported[table.Table] = table
+		if name := schemaName(table); !slices.Contains(schemas, name) {
+			schemas = append(schemas, name)
+		}
+	}
+	buf := bytes.NewBuffer(nil)
+	for _, name := range schemas {
+		buf.WriteString(fmt.Sprintf("schema %s {\n}\n", atlasString(name)))
+	}
+	references := func(kind string, names []string) string {
+		values := make([]string, 0, len(names))
+		for _, name := range names {
+			values = append(values, atlasReference(kind, name))
+		}
+		return strings.Join(values, ", ")
+	}
+	for _, table := range tmp.Tables {
+		buf.WriteString(fmt.Sprintf("\ntable %s {\n", atlasString(table.Table)))
+		buf.WriteString(fmt.Sprintf("  schema = %s\n", atlasReference("schema", schemaName(table))))
+		if table.Comment != "" {
+			buf.WriteString(fmt.Sprintf("  comment = %s\n", atlasString(table.Comment)))
+		}
+		for _, column := range table.Columns {
+			columnType, unsigned, err := atlasColumnType(dialect, column)
+			if err != nil {
+				return nil, fmt.Errorf("atlas: table %s: %w", table.Table, err)
+			}
+			buf.WriteString(fmt.Sprintf("  column %s {\n", atlasString(column.Column)))
+			buf.WriteString(fmt.Sprintf("    null = %t\n", column.nullable()))
+			buf.WriteString(fmt.Sprintf("    type = %s\n", columnType))
+			if unsigned {
+				buf.WriteString("    unsigned = true\n")
+			}
+			if value := atlasDefault(dialect, table, column); value != "" {
+				buf.WriteString(fmt.Sprintf("    default = %s\n", value))
+			}
+			if column.Column == table.AutoIncrementColumn {
+				if dialect == string(cst.Postgresql) {
+					buf.WriteString("    identity {\n      generated = BY_DEFAULT\n    }\n")
+				} else {
+					buf.WriteString("    auto_increment = true\n")
+				}
+			}
+			if column.Comment != "" {
+				buf.WriteString(fmt.Sprintf("    comment = %s\n", atlasString(column.Comment)))
+			}
+			buf.WriteString("  }\n")
+		}
+		for _, index := range table.Indexes {
+			if index.Primary {
+				buf.WriteString(fmt.Sprintf("  primary_key {\n    columns = [%s]\n  }\n", references("column", index.Columns)))
+			}
+		}
+		for _, foreignKey := range table.ForeignKeys {
+			referencedTable, ok := exported[foreignKey.ReferencedTable]
+			if !ok {
+				continue
+			}
+			columns := foreignKey.ReferencedColumns
+			// The referenced columns of SQLite are empty when the referenced table has no primary key of the foreign key columns
+			if slices.Contains(columns, "") {
+				columns = nil
+				for _, index := range referencedTable.Indexes {
+					if index.Primary {
+						columns = index.Columns
+					}
+				}
+			}
+			if len(columns) != len(foreignKey.Columns) {
+				continue
+			}
+			referenced := make([]string, 0, len(columns))
+			for _, column := range columns {
+				referenced = append(referenced, atlasReference("table", foreignKey.ReferencedTable)+"."+atlasReference("column", column))
+			}
+			buf.WriteString(fmt.Sprintf("  foreign_key %s {\n", atlasString(migrationForeignKeyName(table, foreignKey))))
+			buf.WriteString(fmt.Sprintf("    columns = [%s]\n", references("column", foreignKey.Columns)))
+			buf.WriteString(fmt.Sprintf("    ref_columns = [%s]\n", strings.Join(referenced, ", ")))
+			if action := atlasAction(foreignKey.OnUpdate); action != "" {
+				buf.WriteString(fmt.Sprintf("    on_update = %s\n", action))
+			}
+			if action := atlasAction(foreignKey.OnDelete); action != "" {
+				buf.WriteString(fmt.Sprintf("    on_delete = %s\n", action))
+			}
+			buf.WriteString("  }\n")
+		}
+		for _, index := range table.Indexes {
+			if index.Primary || len(index.Columns) == 0 {
+				continue
+			}
+			buf.WriteString(fmt.Sprintf("  index %s {\n", atlasString(index.Name)))
+			if index.Unique {
+				buf.WriteString("    unique = true\n")
+			}
+			buf.WriteString(fmt.Sprintf("    columns = [%s]\n", references("column", index.Columns)))
+			if index.Method != "" && index.Method != "btree" {
+				buf.WriteString(fmt.Sprintf("    type = %s\n", strings.ToUpper(index.Method)))
+			}
+			if index.Where != "" {
+				buf.WriteString(fmt.Sprintf("    where = %s\n", atlasString(index.Where)))
+			}
+			buf.WriteString("  }\n")
+		}
+		buf.WriteString("}\n")
+	}
+	return buf.Bytes(), nil
+}
+
+// ExportAtlas Translate the exported tables into the Atlas HCL schema, such as schema.hcl of atlas schema apply.
+func (s *App) ExportAtlas(ctx context.Context) ([]byte, error) {
+	return s.Run(ctx, func(ctx context.Context, tmp *Template) ([]byte, error) {
+		content, err := atlasHCL(tmp)
+		if err != nil {
+			return nil, err
+		}
+		return formatOutput(s.cfg, content), nil
+	})
+}
@@ -0,0 +1,123 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/cd365/hey/v7/cst"
+	"github.com/go-sql-driver/mysql"
+)
+
+const (
+	AuthRdsIam  = "rds_iam"
+	AuthGcpIam  = "gcp_iam"
+	AuthCommand = "command"
+)
+
+// authProviders Short-lived password providers of the auth types, rds_iam and gcp_iam are only compiled in with the aws and gcp build tags.
+var authProviders = map[string]func(ctx context.Context, cfg *Config) (string, error){
+	AuthCommand: authCommand,
+}
+
+// authBuildTags Build tags required by the auth types.
+var authBuildTags = map[string]string{
+	AuthRdsIam: "aws",
+	AuthGcpIam: "gcp",
+}
+
+// applyAuth Replace the password with a short-lived password of the configured auth type, the password in the database url, replica
+// or data_source_name is replaced too; the data_source_name must be a connection URI or a mysql driver DSN then.
+func applyAuth(ctx context.Context, cfg *Config) error {
+	kind := cfg.Database.Auth.Type
+	if kind == "" {
+		return nil
+	}
+	provider, ok := authProviders[kind]
+	if !ok {
+		if tag, ok := authBuildTags[kind]; ok {
+			return fmt.Errorf("the %s auth requires pts built with the %s build tag", kind, tag)
+		}
+		return fmt.Errorf("unsupported auth type: %s", kind)
+	}
+	var inject func(password string)
+	if value := databaseUrl(cfg); *value != "" {
+		u, err := url.Parse(*value)
+		if err != nil {
+			return fmt.Errorf("invalid database url: %w", err)
+		}
+		authHost(cfg, u)
+		inject = func(password string) {
+			u.User = url.UserPassword(cfg.Database.Username, password)
+			*value = u.String()
+		}
+	} else if dsn := strings.TrimSpace(cfg.Database.DataSourceName); dsn != "" {
+		switch cfg.Database.Driver {
+		case string(cst.Mysql), DriverTidb, DriverMariadb:
+			c, err := mysql.ParseDSN(dsn)
+			if err != nil {
+				return fmt.Errorf("%s auth: invalid data_source_name: %w", kind, err)
+			}
+			cfg.Database.Username = c.User
+			if host, port, err := net.SplitHostPort(c.Addr); err == nil {
+				cfg.Database.Host = host
+				if port, err := strconv.ParseUint(port, 10, 16); err == nil {
+					cfg.Database.Port = uint16(port)
+				}
+			}
+			inject = func(password string) {
+				c.Passwd = password
+				cfg.Database.DataSourceName = c.FormatDSN()
+			}
+		default:
+			u, err := url.Parse(dsn)
+			if err != nil || u.Scheme == "" || u.Host == "" {
+				return fmt.Errorf("%s auth: the data_source_name must be a connection URI such as postgres://user@host:port/database, or use url or the host settings", kind)
+			}
+			authHost(cfg, u)
+			inject = func(password string) {
+				u.User = url.UserPassword(cfg.Database.Username, password)
+				cfg.Database.DataSourceName = u.String()
+			}
+		}
+	}
+	password, err := provider(ctx, cfg)
+	if err != nil {
+		return fmt.Errorf("%s auth: %w", kind, err)
+	}
+	cfg.Database.Password = password
+	if inject != nil {
+		inject(password)
+	}
+	return nil
+}
+
+// authHost Take the username, host and port of the connection URI, the token is generated for them.
+func authHost(cfg *Config, u *url.URL) {
+	if u.User != nil && u.User.Username() != "" {
+		cfg.Database.Username = u.User.Username()
+	}
+	if u.Hostname() != "" {
+		cfg.Database.Host = u.Hostname()
+	}
+	if port, err := strconv.ParseUint(u.Port(), 10, 16); err == nil {
+		cfg.Database.Port = uint16(port)
+	}
+}
+
+// authCommand The output of the command is the password, such as aws rds generate-db-auth-token or gcloud sql generate-login-token.
+func authCommand(ctx context.Context, cfg *Config) (string, error) {
+	command := cfg.Database.Auth.Command
+	if len(command) == 0 {
+		return "", fmt.Errorf("the auth.command value is not configured")
+	}
+	output, err := exec.CommandContext(ctx, command[0], command[1:]...).Output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(output)), nil
+}
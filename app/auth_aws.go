@@ -0,0 +1,59 @@
+//go:build aws
+
+package app
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+)
+
+func init() {
+	authProviders[AuthRdsIam] = authRdsIam
+}
+
+// rdsIamEmptyPayload The SHA-256 of the empty payload of the signed connect request.
+const rdsIamEmptyPayload = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+// authRdsIam AWS RDS IAM authentication token, the credentials are loaded from the default AWS credential chain.
+// The token is the connect request presigned with SigV4 for 15 minutes, without the scheme.
+func authRdsIam(ctx context.Context, cfg *Config) (string, error) {
+	awsConfig, err := awsconfig.LoadDefaultConfig(ctx)
+	if err != nil {
+		return "", err
+	}
+	region := cfg.Database.Auth.Region
+	if region == "" {
+		region = awsConfig.Region
+	}
+	if region == "" {
+		return "", fmt.Errorf("the auth.region value is not configured and there is no default AWS region")
+	}
+	if awsConfig.Credentials == nil {
+		return "", fmt.Errorf("there are no AWS credentials")
+	}
+	credentials, err := awsConfig.Credentials.Retrieve(ctx)
+	if err != nil {
+		return "", err
+	}
+	query := url.Values{}
+	query.Set("Action", "connect")
+	query.Set("DBUser", cfg.Database.Username)
+	query.Set("X-Amz-Expires", "900")
+	endpoint := fmt.Sprintf("https://%s:%d/?%s", cfg.Database.Host, cfg.Database.Port, query.Encode())
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return "", err
+	}
+	signed, _, err := v4.NewSigner().PresignHTTP(ctx, credentials, request, rdsIamEmptyPayload, "rds-db", region, time.Now().UTC())
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimPrefix(signed, "https://"), nil
+}
@@ -0,0 +1,26 @@
+//go:build gcp
+
+package app
+
+import (
+	"context"
+
+	"golang.org/x/oauth2/google"
+)
+
+func init() {
+	authProviders[AuthGcpIam] = authGcpIam
+}
+
+// authGcpIam GCP Cloud SQL IAM database authentication, the OAuth2 access token of the application default credentials is the password.
+func authGcpIam(ctx context.Context, cfg *Config) (string, error) {
+	source, err := google.DefaultTokenSource(ctx, "https://www.googleapis.com/auth/sqlservice.login")
+	if err != nil {
+		return "", err
+	}
+	token, err := source.Token()
+	if err != nil {
+		return "", err
+	}
+	return token.AccessToken, nil
+}
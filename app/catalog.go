@@ -0,0 +1,301 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	CatalogCsv          = "csv"
+	CatalogOpenMetadata = "openmetadata"
+	CatalogDatahub      = "datahub"
+)
+
+// catalogTable The descriptions of a table and its columns in the catalog.
+type catalogTable struct {
+	Description string
+	Columns     map[string]string
+}
+
+// catalogConfig The configuration with the descriptions of the catalog merged into the comments, the configuration itself if the catalog is not configured.
+// The configured comments take precedence over the descriptions, the comments of the configuration are not modified.
+func catalogConfig(ctx context.Context, cfg *Config, tables []*Table) (*Config, error) {
+	if cfg.Catalog.Type == "" {
+		return cfg, nil
+	}
+	descriptions, err := catalogTables(ctx, cfg, tables)
+	if err != nil {
+		return nil, fmt.Errorf("catalog %s: %w", cfg.Catalog.Type, err)
+	}
+	comments := make(map[string]struct {
+		Comment string            `yaml:"comment"`
+		Columns map[string]string `yaml:"columns"`
+	}, len(cfg.Comments)+len(descriptions))
+	for k, v := range cfg.Comments {
+		comments[k] = v
+	}
+	for k, description := range descriptions {
+		v := comments[k]
+		if v.Comment == "" {
+			v.Comment = description.Description
+		}
+		columns := make(map[string]string, len(v.Columns)+len(description.Columns))
+		for column, comment := range description.Columns {
+			columns[column] = comment
+		}
+		for column, comment := range v.Columns {
+			if comment != "" {
+				columns[column] = comment
+			}
+		}
+		v.Columns = columns
+		comments[k] = v
+	}
+	result := *cfg
+	result.Comments = comments
+	return &result, nil
+}
+
+// catalogTables The descriptions of the tables in the catalog by the table key, the tables missing in the catalog are omitted.
+func catalogTables(ctx context.Context, cfg *Config, tables []*Table) (map[string]*catalogTable, error) {
+	switch cfg.Catalog.Type {
+	case CatalogCsv:
+		return catalogCsv(cfg, tables)
+	case CatalogOpenMetadata, CatalogDatahub:
+		if cfg.Catalog.Url == "" || !strings.Contains(cfg.Catalog.Name, "%s") {
+			return nil, fmt.Errorf("the url and the name containing %%s must be configured")
+		}
+		token, err := resolveSecret(ctx, cfg.Catalog.Token, true)
+		if err != nil {
+			return nil, err
+		}
+		result := make(map[string]*catalogTable, len(tables))
+		for _, table := range tables {
+			var description *catalogTable
+			if cfg.Catalog.Type == CatalogOpenMetadata {
+				description, err = catalogOpenMetadata(ctx, cfg, token, table.Table)
+			} else {
+				description, err = catalogDatahub(ctx, cfg, token, table.Table)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("table %s: %w", table.Table, err)
+			}
+			if description != nil {
+				result[tableKey(cfg, table.Table)] = description
+			}
+		}
+		return result, nil
+	}
+	return nil, fmt.Errorf("unsupported catalog type, supported types: csv, openmetadata, datahub")
+}
+
+// catalogCsv Read the descriptions of the CSV export, the header names the table, column and description columns in any order.
+// The rows of the tables that are not exported are ignored.
+func catalogCsv(cfg *Config, tables []*Table) (map[string]*catalogTable, error) {
+	file, err := os.Open(cfg.Catalog.File)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = file.Close() }()
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+	header, err := reader.Read()
+	if err != nil {
+		return nil, err
+	}
+	index := map[string]int{"table": -1, "column": -1, "description": -1}
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if _, ok := index[name]; ok {
+			index[name] = i
+		}
+	}
+	if index["table"] < 0 || index["description"] < 0 {
+		return nil, fmt.Errorf("%s: the header must name the table and description columns", cfg.Catalog.File)
+	}
+	exported := make(map[string]*struct{}, len(tables))
+	for _, table := range tables {
+		exported[tableKey(cfg, table.Table)] = nil
+	}
+	field := func(record []string, name string) string {
+		if i := index[name]; i >= 0 && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+	result := make(map[string]*catalogTable)
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		key := tableKey(cfg, field(record, "table"))
+		if _, ok := exported[key]; !ok {
+			continue
+		}
+		description := result[key]
+		if description == nil {
+			description = &catalogTable{Columns: make(map[string]string)}
+			result[key] = description
+		}
+		if column := field(record, "column"); column != "" {
+			description.Columns[column] = field(record, "description")
+		} else {
+			description.Description = field(record, "description")
+		}
+	}
+	return result, nil
+}
+
+// catalogRequest Send the request with the bearer token and decode the JSON response, false if the entity is not found.
+func catalogRequest(ctx context.Context, token string, method string, endpoint string, body any, result any) (bool, error) {
+	var reader io.Reader
+	if body != nil {
+		content, err := json.Marshal(body)
+		if err != nil {
+			return false, err
+		}
+		reader = bytes.NewReader(content)
+	}
+	ctx, cancel := context.WithTimeout(ctx, time.Second*10)
+	defer cancel()
+	request, err := http.NewRequestWithContext(ctx, method, endpoint, reader)
+	if err != nil {
+		return false, err
+	}
+	request.Header.Set("Accept", "application/json")
+	if body != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+	if token != "" {
+		request.Header.Set("Authorization", "Bearer "+token)
+	}
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return false, err
+	}
+	defer func() { _ = response.Body.Close() }()
+	if response.StatusCode == http.StatusNotFound {
+		return false, nil
+	}
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return false, fmt.Errorf("unexpected status %s", response.Status)
+	}
+	return true, json.NewDecoder(response.Body).Decode(result)
+}
+
+// catalogOpenMetadata The descriptions of the table entity of OpenMetadata by the fully qualified name, nil if the table is not found.
+func catalogOpenMetadata(ctx context.Context, cfg *Config, token string, table string) (*catalogTable, error) {
+	endpoint := fmt.Sprintf("%s/api/v1/tables/name/%s?fields=columns", strings.TrimRight(cfg.Catalog.Url, "/"), url.PathEscape(fmt.Sprintf(cfg.Catalog.Name, table)))
+	entity := &struct {
+		Description string `json:"description"`
+		Columns     []struct {
+			Name        string `json:"name"`
+			Description string `json:"description"`
+		} `json:"columns"`
+	}{}
+	found, err := catalogRequest(ctx, token, http.MethodGet, endpoint, nil, entity)
+	if err != nil || !found {
+		return nil, err
+	}
+	result := &catalogTable{Description: entity.Description, Columns: make(map[string]string, len(entity.Columns))}
+	for _, column := range entity.Columns {
+		if column.Description != "" {
+			result.Columns[column.Name] = column.Description
+		}
+	}
+	return result, nil
+}
+
+// datahubQuery The GraphQL query of the dataset descriptions, the descriptions edited in DataHub take precedence over the ingested descriptions.
+const datahubQuery = `query ($urn: String!) { dataset(urn: $urn) {
+	properties { description }
+	editableProperties { description }
+	schemaMetadata { fields { fieldPath description } }
+	editableSchemaMetadata { editableSchemaFieldInfo { fieldPath description } }
+} }`
+
+// catalogDatahub The descriptions of the dataset of DataHub by the urn, nil if the dataset is not found.
+func catalogDatahub(ctx context.Context, cfg *Config, token string, table string) (*catalogTable, error) {
+	type field struct {
+		FieldPath   string `json:"fieldPath"`
+		Description string `json:"description"`
+	}
+	response := &struct {
+		Data struct {
+			Dataset *struct {
+				Properties *struct {
+					Description string `json:"description"`
+				} `json:"properties"`
+				EditableProperties *struct {
+					Description string `json:"description"`
+				} `json:"editableProperties"`
+				SchemaMetadata *struct {
+					Fields []field `json:"fields"`
+				} `json:"schemaMetadata"`
+				EditableSchemaMetadata *struct {
+					EditableSchemaFieldInfo []field `json:"editableSchemaFieldInfo"`
+				} `json:"editableSchemaMetadata"`
+			} `json:"dataset"`
+		} `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}{}
+	body := map[string]any{
+		"query":     datahubQuery,
+		"variables": map[string]any{"urn": fmt.Sprintf(cfg.Catalog.Name, table)},
+	}
+	endpoint := strings.TrimRight(cfg.Catalog.Url, "/") + "/api/graphql"
+	found, err := catalogRequest(ctx, token, http.MethodPost, endpoint, body, response)
+	if err != nil || !found {
+		return nil, err
+	}
+	if len(response.Errors) > 0 {
+		return nil, errors.New(response.Errors[0].Message)
+	}
+	dataset := response.Data.Dataset
+	if dataset == nil {
+		return nil, nil
+	}
+	result := &catalogTable{Columns: make(map[string]string)}
+	if dataset.Properties != nil {
+		result.Description = dataset.Properties.Description
+	}
+	if dataset.EditableProperties != nil && dataset.EditableProperties.Description != "" {
+		result.Description = dataset.EditableProperties.Description
+	}
+	// The field paths of the version 2 paths are prefixed with the types, [version=2.0].[type=long].id => id
+	column := func(fieldPath string) string {
+		if strings.HasPrefix(fieldPath, "[version=") {
+			return fieldPath[strings.LastIndex(fieldPath, ".")+1:]
+		}
+		return fieldPath
+	}
+	fields := make([]field, 0)
+	if dataset.SchemaMetadata != nil {
+		fields = append(fields, dataset.SchemaMetadata.Fields...)
+	}
+	if dataset.EditableSchemaMetadata != nil {
+		fields = append(fields, dataset.EditableSchemaMetadata.EditableSchemaFieldInfo...)
+	}
+	for _, v := range fields {
+		if v.Description != "" {
+			result.Columns[column(v.FieldPath)] = v.Description
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,188 @@
+package app
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/cd365/hey/v7/cst"
+)
+
+// ConnectSchema Kafka Connect schema, the JSON of the schema of the JsonConverter with schemas.enable.
+type ConnectSchema struct {
+	Type       string            `json:"type"`                 // int8, int16, int32, int64, float32, float64, boolean, string, bytes, struct
+	Optional   bool              `json:"optional"`             // the value may be null
+	Name       string            `json:"name,omitempty"`       // logical type name, such as io.debezium.time.Date, or the struct name
+	Version    int               `json:"version,omitempty"`    // version of the logical type
+	Parameters map[string]string `json:"parameters,omitempty"` // parameters of the logical type, such as the scale of decimals
+	Doc        string            `json:"doc,omitempty"`        // column comment
+	Field      string            `json:"field,omitempty"`      // field name of a struct field, the column name
+	Fields     []*ConnectSchema  `json:"fields,omitempty"`     // fields of a struct
+}
+
+// CdcTable Schemas of the change events of a table, as produced by Debezium with the default converters.
+type CdcTable struct {
+	Table    string         `json:"table"`    // table name
+	Topic    string         `json:"topic"`    // topic of the change events: topic prefix, database or schema, table
+	Key      *ConnectSchema `json:"key"`      // message key, the primary key columns; nil if the table has no primary key
+	Value    *ConnectSchema `json:"value"`    // row of the table, the before and after fields of the envelope
+	Envelope *ConnectSchema `json:"envelope"` // message value: before, after, op, ts_ms; the connector specific source field is not included
+}
+
+// cdcTopic The topic of the change events of the table, the empty parts are omitted.
+func cdcTopic(cfg *Config, table *Table) string {
+	parts := make([]string, 0, 3)
+	for _, part := range []string{cfg.Cdc.TopicPrefix, table.Database, table.Table} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, ".")
+}
+
+// connectField The Kafka Connect schema of the column, the logical types are the Debezium defaults: decimal.handling.mode precise, time.precision.mode adaptive_time_microseconds.
+func connectField(dialect string, column *Column) *ConnectSchema {
+	field := &ConnectSchema{
+		Optional: column.nullable(),
+		Doc:      column.Comment,
+		Field:    column.Column,
+	}
+	mysql := dialect == string(cst.Mysql)
+	unsigned := column.Type != nil && strings.Contains(strings.ToLower(*column.Type), "unsigned")
+	logical := func(kind string, name string) {
+		field.Type, field.Name, field.Version = kind, name, 1
+	}
+	switch column.dataType() {
+	case "tinyint":
+		field.Type = "int16"
+	case "smallint", "smallserial":
+		field.Type = "int16"
+		if unsigned {
+			field.Type = "int32"
+		}
+	case "mediumint":
+		field.Type = "int32"
+	case "integer", "int", "serial":
+		field.Type = "int32"
+		if unsigned {
+			field.Type = "int64"
+		}
+	case "bigint", "bigserial":
+		field.Type = "int64"
+	case "real", "float":
+		field.Type = "float32"
+	case "double", "double precision":
+		field.Type = "float64"
+	case "decimal", "numeric", "number":
+		logical("bytes", "org.apache.kafka.connect.data.Decimal")
+		field.Parameters = map[string]string{"scale": strconv.Itoa(exprInt(column.NumericScale))}
+		if column.NumericPrecision != nil {
+			field.Parameters["connect.decimal.precision"] = strconv.Itoa(*column.NumericPrecision)
+		}
+	case "bool", "boolean":
+		field.Type = "boolean"
+	case "bit":
+		field.Type = "boolean"
+		if exprInt(column.CharacterMaximumLength) > 1 || exprInt(column.NumericPrecision) > 1 {
+			logical("bytes", "io.debezium.data.Bits")
+		}
+	case "date":
+		logical("int32", "io.debezium.time.Date")
+	case "time", "time without time zone":
+		logical("int64", "io.debezium.time.MicroTime")
+	case "timetz", "time with time zone":
+		logical("string", "io.debezium.time.ZonedTime")
+	case "datetime", "timestamp without time zone", "seconddate":
+		logical("int64", "io.debezium.time.MicroTimestamp")
+		// adaptive: millisecond precision at most, the precision of MySQL DATETIME is 0 unless it is declared
+		if (mysql || column.DatetimePrecision != nil) && exprInt(column.DatetimePrecision) <= 3 {
+			logical("int64", "io.debezium.time.Timestamp")
+		}
+	case "timestamp":
+		// MySQL TIMESTAMP is stored in UTC, PostgreSQL timestamp is without time zone
+		logical("int64", "io.debezium.time.MicroTimestamp")
+		if mysql {
+			logical("string", "io.debezium.time.ZonedTimestamp")
+		}
+	case "timestamptz", "timestamp with time zone":
+		logical("string", "io.debezium.time.ZonedTimestamp")
+	case "year":
+		logical("int32", "io.debezium.time.Year")
+	case "uuid":
+		logical("string", "io.debezium.data.Uuid")
+	case "json", "jsonb":
+		logical("string", "io.debezium.data.Json")
+	case "enum":
+		logical("string", "io.debezium.data.Enum")
+		if column.Type != nil {
+			// enum('a','b') => a,b
+			values := strings.TrimSuffix(strings.TrimPrefix(*column.Type, "enum("), ")")
+			field.Parameters = map[string]string{"allowed": strings.ReplaceAll(values, "'", "")}
+		}
+	case "binary", "varbinary", "tinyblob", "blob", "mediumblob", "longblob", "bytea", "raw":
+		field.Type = "bytes"
+	default:
+		field.Type = "string"
+	}
+	return field
+}
+
+// cdcTables The change event schemas of the tables.
+func cdcTables(cfg *Config, tmp *Template) []*CdcTable {
+	result := make([]*CdcTable, 0, len(tmp.Tables))
+	for _, table := range tmp.Tables {
+		topic := cdcTopic(cfg, table)
+		value := &ConnectSchema{
+			Type:     "struct",
+			Optional: true,
+			Name:     topic + ".Value",
+			Doc:      table.Comment,
+			Fields:   make([]*ConnectSchema, 0, len(table.Columns)),
+		}
+		columns := make(map[string]*Column, len(table.Columns))
+		for _, column := range table.Columns {
+			value.Fields = append(value.Fields, connectField(tmp.Dialect, column))
+			columns[column.Column] = column
+		}
+		cdc := &CdcTable{
+			Table: table.Table,
+			Topic: topic,
+			Value: value,
+		}
+		for _, index := range table.Indexes {
+			if !index.Primary {
+				continue
+			}
+			cdc.Key = &ConnectSchema{
+				Type: "struct",
+				Name: topic + ".Key",
+			}
+			for _, name := range index.Columns {
+				if column, ok := columns[name]; ok {
+					field := connectField(tmp.Dialect, column)
+					field.Optional, field.Doc = false, ""
+					cdc.Key.Fields = append(cdc.Key.Fields, field)
+				}
+			}
+		}
+		before, after := *value, *value
+		before.Field, after.Field = "before", "after"
+		cdc.Envelope = &ConnectSchema{
+			Type: "struct",
+			Name: topic + ".Envelope",
+			Fields: []*ConnectSchema{
+				&before,
+				&after,
+				{Type: "string", Field: "op"}, // c, u, d, r
+				{Type: "int64", Optional: true, Field: "ts_ms"},
+			},
+		}
+		result = append(result, cdc)
+	}
+	return result
+}
+
+// cdcJSON The change event schemas of the tables as indented JSON.
+func cdcJSON(cfg *Config, tmp *Template) ([]byte, error) {
+	return json.MarshalIndent(cdcTables(cfg, tmp), "", "\t")
+}
@@ -0,0 +1,45 @@
+package app
+
+import (
+	_ "embed"
+	"errors"
+	"os"
+)
+
+//go:embed template/demo_schema.json
+var demoSchema []byte
+
+// NewDemoApp Use the built-in demo schema instead of a database connection, the configuration file is optional.
+func NewDemoApp(config string) (app *App, err error) {
+	cfg := &Config{}
+	if config != "" {
+		if _, err = os.Stat(config); err == nil {
+			cfg, err = ParseConfig(config)
+			if err != nil {
+				return
+			}
+		} else if !errors.Is(err, os.ErrNotExist) {
+			return
+		}
+	}
+	if err = initConfig(cfg); err != nil {
+		return
+	}
+	app = &App{
+		cfg: cfg,
+	}
+	err = nil
+	return
+}
+
+// GetDemoTables Get all tables and their columns in the built-in demo schema that meet the criteria
+func GetDemoTables(config *Config) ([]*Table, error) {
+	tmp, err := ParseSnapshot(demoSchema)
+	if err != nil {
+		return nil, err
+	}
+	tables := filterTables(config, tmp.Tables)
+	excludeColumns(config, tables)
+	initTables(config, tables)
+	return tables, nil
+}
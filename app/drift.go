@@ -0,0 +1,324 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	DriftReportText     = "text"     // one change per line
+	DriftReportMarkdown = "markdown" // GitHub flavored Markdown table of the changes, such as a pull request comment
+)
+
+// Drift Tables changed since the baseline snapshot, posted to the webhook as JSON.
+type Drift struct {
+	Added        []string       `json:"added,omitempty"`     // tables not in the baseline snapshot
+	Removed      []string       `json:"removed,omitempty"`   // tables of the baseline snapshot that are no longer exported
+	Changed      []string       `json:"changed,omitempty"`   // tables whose schema hash changed
+	Reordered    []string       `json:"reordered,omitempty"` // changed tables whose columns of the baseline are in a different order, such as a MySQL column moved by AFTER
+	Details      []*DriftChange `json:"details,omitempty"`   // column, index, foreign key and comment changes of the changed tables
+	Hash         string         `json:"hash"`                // hash of the schema hashes of all tables
+	PreviousHash string         `json:"previous_hash"`       // hash of the schema hashes of all tables of the baseline snapshot
+}
+
+// DriftChange A change of a column, index, foreign key or comment of a changed table.
+type DriftChange struct {
+	Table  string `json:"table"`            // table name
+	Kind   string `json:"kind"`             // column, index, foreign key, comment
+	Name   string `json:"name,omitempty"`   // name of the column, index or foreign key; empty for the comment
+	Change string `json:"change"`           // added, removed, altered
+	Before string `json:"before,omitempty"` // definition of the baseline, such as varchar(64) NOT NULL; empty if added
+	After  string `json:"after,omitempty"`  // current definition, empty if removed
+}
+
+// WithDriftReport Use the report format instead of drift.report: text, markdown.
+func WithDriftReport(format string) Option {
+	return func(cfg *Config) {
+		cfg.Drift.Report = format
+	}
+}
+
+// driftColumn The definition of the column, such as varchar(64) NOT NULL DEFAULT 'none'.
+func driftColumn(column *Column) string {
+	definition := column.dataType()
+	if column.Type != nil && *column.Type != "" {
+		definition = *column.Type
+	}
+	if !column.nullable() {
+		definition += " NOT NULL"
+	}
+	if column.ColumnDefault != nil {
+		definition += " DEFAULT " + *column.ColumnDefault
+	}
+	if column.Extra != nil && *column.Extra != "" {
+		definition += " " + *column.Extra
+	}
+	return definition
+}
+
+// driftIndex The definition of the index, such as UNIQUE (tenant_id, email) WHERE deleted_at IS NULL.
+func driftIndex(index *Index) string {
+	definition := "INDEX"
+	switch {
+	case index.Primary:
+		definition = "PRIMARY KEY"
+	case index.Unique:
+		definition = "UNIQUE"
+	}
+	definition += fmt.Sprintf(" (%s)", strings.Join(index.Columns, ", "))
+	if index.Method != "" {
+		definition += " USING " + index.Method
+	}
+	if index.Where != "" {
+		definition += " WHERE " + index.Where
+	}
+	return definition
+}
+
+// driftForeignKey The definition of the foreign key, such as (user_id) REFERENCES user (id) ON DELETE CASCADE.
+func driftForeignKey(foreignKey *ForeignKey) string {
+	definition := fmt.Sprintf("(%s) REFERENCES %s (%s)", strings.Join(foreignKey.Columns, ", "), foreignKey.ReferencedTable, strings.Join(foreignKey.ReferencedColumns, ", "))
+	if foreignKey.OnDelete != "" {
+		definition += " ON DELETE " + foreignKey.OnDelete
+	}
+	if foreignKey.OnUpdate != "" {
+		definition += " ON UPDATE " + foreignKey.OnUpdate
+	}
+	return definition
+}
+
+// driftDefinitions Compare the definitions by name in the order of the names, the removed definitions come after the others.
+func driftDefinitions(table string, kind string, previous map[string]string, current map[string]string, names []string) []*DriftChange {
+	changes := make([]*DriftChange, 0)
+	for _, name := range names {
+		before, ok := previous[name]
+		switch {
+		case !ok:
+			changes = append(changes, &DriftChange{Table: table, Kind: kind, Name: name, Change: "added", After: current[name]})
+		case before != current[name]:
+			changes = append(changes, &DriftChange{Table: table, Kind: kind, Name: name, Change: "altered", Before: before, After: current[name]})
+		}
+		delete(previous, name)
+	}
+	removed := make([]string, 0, len(previous))
+	for name := range previous {
+		removed = append(removed, name)
+	}
+	slices.Sort(removed)
+	for _, name := range removed {
+		changes = append(changes, &DriftChange{Table: table, Kind: kind, Name: name, Change: "removed", Before: previous[name]})
+	}
+	return changes
+}
+
+// driftTable The column, index, foreign key and comment changes of the table since the baseline.
+func driftTable(baseline *Table, table *Table) []*DriftChange {
+	changes := make([]*DriftChange, 0)
+	if baseline.Comment != table.Comment {
+		changes = append(changes, &DriftChange{Table: table.Table, Kind: "comment", Change: "altered", Before: baseline.Comment, After: table.Comment})
+	}
+	previous, current, names := make(map[string]string), make(map[string]string), make([]string, 0)
+	for _, column := range baseline.Columns {
+		previous[column.Column] = driftColumn(column)
+	}
+	for _, column := range table.Columns {
+		current[column.Column] = driftColumn(column)
+		names = append(names, column.Column)
+	}
+	changes = append(changes, driftDefinitions(table.Table, "column", previous, current, names)...)
+	previous, current, names = make(map[string]string), make(map[string]string), make([]string, 0)
+	for _, index := range baseline.Indexes {
+		previous[index.Name] = driftIndex(index)
+	}
+	for _, index := range table.Indexes {
+		current[index.Name] = driftIndex(index)
+		names = append(names, index.Name)
+	}
+	changes = append(changes, driftDefinitions(table.Table, "index", previous, current, names)...)
+	previous, current, names = make(map[string]string), make(map[string]string), make([]string, 0)
+	for _, foreignKey := range baseline.ForeignKeys {
+		previous[migrationForeignKeyName(baseline, foreignKey)] = driftForeignKey(foreignKey)
+	}
+	for _, foreignKey := range table.ForeignKeys {
+		name := migrationForeignKeyName(table, foreignKey)
+		current[name] = driftForeignKey(foreignKey)
+		names = append(names, name)
+	}
+	return append(changes, driftDefinitions(table.Table, "foreign key", previous, current, names)...)
+}
+
+// tablesHash Hash of the table names and schema hashes of all tables.
+func tablesHash(tables []*Table) string {
+	hashes := make([]string, 0, len(tables))
+	for _, table := range tables {
+		hashes = append(hashes, table.Table+":"+table.SchemaHash)
+	}
+	slices.Sort(hashes)
+	b, err := json.Marshal(hashes)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:8])
+}
+
+// schemaDrift Compare the tables with the tables of the baseline snapshot, nil if nothing changed.
+func schemaDrift(previous []*Table, current []*Table) *Drift {
+	tables := make(map[string]*Table, len(previous))
+	for _, table := range previous {
+		tables[table.Table] = table
+	}
+	drift := &Drift{
+		Hash:         tablesHash(current),
+		PreviousHash: tablesHash(previous),
+	}
+	if drift.Hash == drift.PreviousHash {
+		return nil
+	}
+	for _, table := range current {
+		baseline, ok := tables[table.Table]
+		switch {
+		case !ok:
+			drift.Added = append(drift.Added, table.Table)
+		case baseline.SchemaHash != table.SchemaHash:
+			drift.Changed = append(drift.Changed, table.Table)
+			if columnsReordered(baseline, table) {
+				drift.Reordered = append(drift.Reordered, table.Table)
+			}
+			drift.Details = append(drift.Details, driftTable(baseline, table)...)
+		}
+		delete(tables, table.Table)
+	}
+	for table := range tables {
+		drift.Removed = append(drift.Removed, table)
+	}
+	slices.Sort(drift.Removed)
+	return drift
+}
+
+// drift Report the tables changed since the baseline snapshot, one change per line, and post the report to the webhook.
+func drift(ctx context.Context, cfg *Config, tmp *Template) ([]byte, error) {
+	if cfg.Drift.Snapshot == "" {
+		return nil, fmt.Errorf("drift: the baseline snapshot is not configured")
+	}
+	content, err := os.ReadFile(cfg.Drift.Snapshot)
+	if err != nil {
+		return nil, err
+	}
+	previous, err := ParseSnapshot(content)
+	if err != nil {
+		return nil, err
+	}
+	// Only compare the tables of the --table flag with the same tables of the baseline
+	baseline := filterTables(cfg, previous.Tables)
+	changes := schemaDrift(baseline, tmp.Tables)
+	if changes == nil {
+		return nil, nil
+	}
+	buf := bytes.NewBuffer(nil)
+	switch cfg.Drift.Report {
+	case DriftReportText, "":
+		for _, v := range [...]struct {
+			change string
+			tables []string
+		}{{"added", changes.Added}, {"removed", changes.Removed}, {"changed", changes.Changed}, {"columns reordered", changes.Reordered}} {
+			for _, table := range v.tables {
+				buf.WriteString(fmt.Sprintf("drift: table %s %s\n", table, v.change))
+			}
+		}
+	case DriftReportMarkdown:
+		buf.Write(driftMarkdown(changes))
+	default:
+		return nil, fmt.Errorf("drift: unsupported report %s, supported reports: %s, %s", cfg.Drift.Report, DriftReportText, DriftReportMarkdown)
+	}
+	if cfg.Drift.Webhook != "" {
+		if err = postWebhook(ctx, cfg, changes); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// driftMarkdown The GitHub flavored Markdown report of the changes: a summary line and a table of the table, column, index, foreign key and comment changes.
+func driftMarkdown(changes *Drift) []byte {
+	// The pipes of the cells are escaped, the line breaks of the comments are spaces
+	code := func(value string) string {
+		if value == "" {
+			return ""
+		}
+		value = strings.NewReplacer("|", "\\|", "\r\n", " ", "\n", " ", "`", "'").Replace(value)
+		return "`" + value + "`"
+	}
+	buf := &strings.Builder{}
+	buf.WriteString("### Schema changes\n\n")
+	buf.WriteString(fmt.Sprintf("%d added, %d removed, %d altered tables; schema hash %s => %s\n\n", len(changes.Added), len(changes.Removed), len(changes.Changed), code(changes.PreviousHash), code(changes.Hash)))
+	buf.WriteString("| Change | Table | Object | Before | After |\n")
+	buf.WriteString("| --- | --- | --- | --- | --- |\n")
+	row := func(change string, table string, object string, before string, after string) {
+		buf.WriteString(fmt.Sprintf("| %s | %s | %s | %s | %s |\n", change, code(table), object, code(before), code(after)))
+	}
+	for _, table := range changes.Added {
+		row("added", table, "table", "", "")
+	}
+	for _, table := range changes.Removed {
+		row("removed", table, "table", "", "")
+	}
+	// Every changed table has a row, the changes of some tables are not column, index, foreign key or comment changes, such as the table options
+	for _, table := range changes.Changed {
+		row("altered", table, "table", "", "")
+	}
+	for _, table := range changes.Reordered {
+		row("reordered", table, "columns", "", "")
+	}
+	for _, v := range changes.Details {
+		object := v.Kind
+		if v.Name != "" {
+			object += " " + code(v.Name)
+		}
+		row(v.Change, v.Table, object, v.Before, v.After)
+	}
+	return []byte(buf.String())
+}
+
+// postWebhook POST the JSON drift report to the webhook, a status other than 2xx is an error.
+func postWebhook(ctx context.Context, cfg *Config, changes *Drift) error {
+	webhook, err := resolveSecret(ctx, cfg.Drift.Webhook, true)
+	if err != nil {
+		return err
+	}
+	body, err := json.Marshal(changes)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, time.Second*10)
+	defer cancel()
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	for k, v := range cfg.Drift.Headers {
+		if v, err = resolveSecret(ctx, v, true); err != nil {
+			return err
+		}
+		request.Header.Set(k, v)
+	}
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return fmt.Errorf("drift webhook: %w", err)
+	}
+	defer func() { _ = response.Body.Close() }()
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return fmt.Errorf("drift webhook: unexpected status %s", response.Status)
+	}
+	return nil
+}
@@ -0,0 +1,7 @@
+//go:build bigquery
+
+package app
+
+import (
+	_ "github.com/solcates/go-sql-bigquery"
+)
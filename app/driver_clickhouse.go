@@ -0,0 +1,7 @@
+//go:build clickhouse
+
+package app
+
+import (
+	_ "github.com/ClickHouse/clickhouse-go/v2"
+)
@@ -0,0 +1,7 @@
+//go:build db2
+
+package app
+
+import (
+	_ "github.com/ibmdb/go_ibm_db"
+)
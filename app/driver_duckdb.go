@@ -0,0 +1,7 @@
+//go:build duckdb
+
+package app
+
+import (
+	_ "github.com/marcboeker/go-duckdb"
+)
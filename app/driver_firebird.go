@@ -0,0 +1,7 @@
+//go:build firebird
+
+package app
+
+import (
+	_ "github.com/nakagami/firebirdsql"
+)
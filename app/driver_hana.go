@@ -0,0 +1,7 @@
+//go:build hana
+
+package app
+
+import (
+	_ "github.com/SAP/go-hdb/driver"
+)
@@ -0,0 +1,7 @@
+//go:build oracle
+
+package app
+
+import (
+	_ "github.com/sijms/go-ora/v2"
+)
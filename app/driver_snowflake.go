@@ -0,0 +1,7 @@
+//go:build snowflake
+
+package app
+
+import (
+	_ "github.com/snowflakedb/gosnowflake"
+)
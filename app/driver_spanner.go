@@ -0,0 +1,7 @@
+//go:build spanner
+
+package app
+
+import (
+	_ "github.com/googleapis/go-sql-spanner"
+)
@@ -0,0 +1,124 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/cd365/hey/v7/cst"
+)
+
+// sqliteType The SQLite column type of the column, chosen by the default go type of the database data type.
+func sqliteType(column *Column) string {
+	switch strings.TrimPrefix(column.goType(), "*") {
+	case "int8", "int16", "int", "int32", "int64", "uint8", "uint16", "uint32", "uint64", "bool":
+		return "INTEGER"
+	case "float32", "float64":
+		switch column.dataType() {
+		case "decimal", "numeric":
+			return "NUMERIC"
+		}
+		return "REAL"
+	case "[]byte":
+		return "BLOB"
+	}
+	return "TEXT"
+}
+
+// sqliteDDL SQLite DDL of the tables, referenced tables come before referencing tables.
+// Column defaults are not translated, the default expressions are dialect specific.
+func sqliteDDL(tmp *Template) []byte {
+	dialect := string(cst.Sqlite)
+	exported := make(map[string]*struct{}, len(tmp.Tables))
+	for _, table := range tmp.Tables {
+		exported[table.Table] = nil
+	}
+	quote := func(names []string) string {
+		quoted := make([]string, 0, len(names))
+		for _, name := range names {
+			quoted = append(quoted, quoteIdentifier(dialect, name))
+		}
+		return strings.Join(quoted, ", ")
+	}
+	buf := bytes.NewBuffer(nil)
+	for _, table := range tmp.TablesTopological {
+		var primary *Index
+		for _, index := range table.Indexes {
+			if index.Primary {
+				primary = index
+			}
+		}
+		// INTEGER PRIMARY KEY is the rowid, it is assigned automatically
+		rowid := primary != nil && len(primary.Columns) == 1 && primary.Columns[0] == table.AutoIncrementColumn
+		lines := make([]string, 0, len(table.Columns)+len(table.ForeignKeys)+1)
+		for _, column := range table.Columns {
+			line := fmt.Sprintf("%s %s", quoteIdentifier(dialect, column.Column), sqliteType(column))
+			if rowid && column.Column == table.AutoIncrementColumn {
+				line = fmt.Sprintf("%s INTEGER PRIMARY KEY AUTOINCREMENT", quoteIdentifier(dialect, column.Column))
+			} else if !column.nullable() {
+				line += " NOT NULL"
+			}
+			lines = append(lines, line)
+		}
+		if primary != nil && !rowid {
+			lines = append(lines, fmt.Sprintf("PRIMARY KEY (%s)", quote(primary.Columns)))
+		}
+		for _, foreignKey := range table.ForeignKeys {
+			if _, ok := exported[foreignKey.ReferencedTable]; !ok {
+				continue
+			}
+			line := fmt.Sprintf("FOREIGN KEY (%s) REFERENCES %s", quote(foreignKey.Columns), quoteIdentifier(dialect, foreignKey.ReferencedTable))
+			if !slices.Contains(foreignKey.ReferencedColumns, "") {
+				line += fmt.Sprintf(" (%s)", quote(foreignKey.ReferencedColumns))
+			}
+			lines = append(lines, line)
+		}
+		buf.WriteString(fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (\n\t%s\n);\n", quoteIdentifier(dialect, table.Table), strings.Join(lines, ",\n\t")))
+		for _, index := range table.Indexes {
+			if index.Primary || len(index.Columns) == 0 {
+				continue
+			}
+			unique := ""
+			if index.Unique {
+				unique = "UNIQUE "
+			}
+			// Index names are unique per database in SQLite, per table in MySQL; sqlite_ names are reserved
+			name := index.Name
+			if tmp.Dialect == string(cst.Mysql) || strings.HasPrefix(name, "sqlite_") {
+				name = fmt.Sprintf("%s_%s", table.Table, index.Name)
+			}
+			buf.WriteString(fmt.Sprintf("CREATE %sINDEX IF NOT EXISTS %s ON %s (%s);\n", unique, quoteIdentifier(dialect, name), quoteIdentifier(dialect, table.Table), quote(index.Columns)))
+		}
+	}
+	return buf.Bytes()
+}
+
+// ExportSqlite Translate the exported tables into SQLite DDL and create the SQLite database file, the DDL is returned if the file is empty.
+func (s *App) ExportSqlite(ctx context.Context, file string) ([]byte, error) {
+	return s.Run(ctx, func(ctx context.Context, tmp *Template) ([]byte, error) {
+		ddl := sqliteDDL(tmp)
+		if file == "" {
+			return formatOutput(s.cfg, ddl), nil
+		}
+		if _, err := os.Stat(file); err == nil {
+			return nil, fmt.Errorf("sqlite database %s already exists", file)
+		} else if !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+		db, err := sql.Open("sqlite3", file)
+		if err != nil {
+			return nil, err
+		}
+		defer func() { _ = db.Close() }()
+		if _, err = db.ExecContext(ctx, string(ddl)); err != nil {
+			_ = os.Remove(file)
+			return nil, err
+		}
+		return nil, nil
+	})
+}
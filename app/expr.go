@@ -0,0 +1,321 @@
+package app
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// columnExpr A compiled column filter expression, such as name =~ '^legacy_' || type == 'longblob'.
+//
+//	expr       := and ( "||" and )*
+//	and        := unary ( "&&" unary )*
+//	unary      := "!" unary | "(" expr ")" | comparison | field
+//	comparison := field ( "==" | "!=" | "=~" | "!~" | "<" | "<=" | ">" | ">=" ) value
+//	value      := 'string' | "string" | integer | true | false
+//
+// A field without comparison is a bool field, such as !nullable.
+type columnExpr interface {
+	eval(table string, column *Column) bool
+}
+
+const (
+	exprKindString = "string"
+	exprKindInt    = "int"
+	exprKindBool   = "bool"
+)
+
+// exprFields The column metadata of the expressions, the value of an unknown number is 0.
+var exprFields = map[string]struct {
+	kind  string
+	value func(table string, column *Column) any
+}{
+	"name":  {exprKindString, func(table string, c *Column) any { return c.Column }},
+	"table": {exprKindString, func(table string, c *Column) any { return table }},
+	"type":  {exprKindString, func(table string, c *Column) any { return c.dataType() }},
+	"column_type": {exprKindString, func(table string, c *Column) any {
+		return strings.ToLower(exprString(c.Type))
+	}},
+	"comment":   {exprKindString, func(table string, c *Column) any { return c.Comment }},
+	"default":   {exprKindString, func(table string, c *Column) any { return exprString(c.ColumnDefault) }},
+	"key":       {exprKindString, func(table string, c *Column) any { return exprString(c.ColumnKey) }},
+	"extra":     {exprKindString, func(table string, c *Column) any { return exprString(c.Extra) }},
+	"nullable":  {exprKindBool, func(table string, c *Column) any { return c.nullable() }},
+	"length":    {exprKindInt, func(table string, c *Column) any { return exprInt(c.CharacterMaximumLength) }},
+	"precision": {exprKindInt, func(table string, c *Column) any { return exprInt(c.NumericPrecision) }},
+	"scale":     {exprKindInt, func(table string, c *Column) any { return exprInt(c.NumericScale) }},
+	"position":  {exprKindInt, func(table string, c *Column) any { return exprInt(c.OrdinalPosition) }},
+}
+
+func exprString(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+
+func exprInt(value *int) int {
+	if value == nil {
+		return 0
+	}
+	return *value
+}
+
+type exprOr struct {
+	left, right columnExpr
+}
+
+func (s *exprOr) eval(table string, column *Column) bool {
+	return s.left.eval(table, column) || s.right.eval(table, column)
+}
+
+type exprAnd struct {
+	left, right columnExpr
+}
+
+func (s *exprAnd) eval(table string, column *Column) bool {
+	return s.left.eval(table, column) && s.right.eval(table, column)
+}
+
+type exprNot struct {
+	expr columnExpr
+}
+
+func (s *exprNot) eval(table string, column *Column) bool {
+	return !s.expr.eval(table, column)
+}
+
+type exprField struct {
+	field string
+}
+
+func (s *exprField) eval(table string, column *Column) bool {
+	return exprFields[s.field].value(table, column).(bool)
+}
+
+type exprCompare struct {
+	field  string
+	op     string
+	value  any
+	regexp *regexp.Regexp
+}
+
+func (s *exprCompare) eval(table string, column *Column) bool {
+	value := exprFields[s.field].value(table, column)
+	switch s.op {
+	case "==":
+		return value == s.value
+	case "!=":
+		return value != s.value
+	case "=~":
+		return s.regexp.MatchString(value.(string))
+	case "!~":
+		return !s.regexp.MatchString(value.(string))
+	}
+	x, y := value.(int), s.value.(int)
+	switch s.op {
+	case "<":
+		return x < y
+	case "<=":
+		return x <= y
+	case ">":
+		return x > y
+	}
+	return x >= y
+}
+
+// exprParser Recursive descent parser of the column filter expressions.
+type exprParser struct {
+	source string
+	tokens []string
+	index  int
+}
+
+// parseColumnExpr Compile the column filter expression.
+func parseColumnExpr(source string) (columnExpr, error) {
+	tokens, err := exprTokens(source)
+	if err != nil {
+		return nil, fmt.Errorf("expression %q: %w", source, err)
+	}
+	p := &exprParser{source: source, tokens: tokens}
+	expr, err := p.or()
+	if err == nil && p.index < len(p.tokens) {
+		err = fmt.Errorf("unexpected %s", p.tokens[p.index])
+	}
+	if err != nil {
+		return nil, fmt.Errorf("expression %q: %w", source, err)
+	}
+	return expr, nil
+}
+
+// exprTokens Split the expression into operators, parentheses, identifiers, numbers and quoted strings.
+func exprTokens(source string) ([]string, error) {
+	tokens := make([]string, 0)
+	for i := 0; i < len(source); {
+		c := source[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '(' || c == ')':
+			tokens = append(tokens, string(c))
+			i++
+		case c == '\'' || c == '"':
+			j := i + 1
+			for j < len(source) && source[j] != c {
+				if source[j] == '\\' {
+					j++
+				}
+				j++
+			}
+			if j >= len(source) {
+				return nil, fmt.Errorf("unterminated string %s", source[i:])
+			}
+			tokens = append(tokens, source[i:j+1])
+			i = j + 1
+		case strings.ContainsRune("=!~<>|&", rune(c)):
+			j := i + 1
+			for j < len(source) && j-i < 2 && strings.ContainsRune("=~|&", rune(source[j])) {
+				j++
+			}
+			tokens = append(tokens, source[i:j])
+			i = j
+		case c == '_' || c == '-' || unicode.IsLetter(rune(c)) || unicode.IsDigit(rune(c)):
+			j := i + 1
+			for j < len(source) && (source[j] == '_' || unicode.IsLetter(rune(source[j])) || unicode.IsDigit(rune(source[j]))) {
+				j++
+			}
+			tokens = append(tokens, source[i:j])
+			i = j
+		default:
+			return nil, fmt.Errorf("unexpected character %q", c)
+		}
+	}
+	return tokens, nil
+}
+
+func (s *exprParser) peek() string {
+	if s.index < len(s.tokens) {
+		return s.tokens[s.index]
+	}
+	return ""
+}
+
+func (s *exprParser) next() string {
+	token := s.peek()
+	if token != "" {
+		s.index++
+	}
+	return token
+}
+
+func (s *exprParser) or() (columnExpr, error) {
+	left, err := s.and()
+	if err != nil {
+		return nil, err
+	}
+	for s.peek() == "||" {
+		s.next()
+		right, err := s.and()
+		if err != nil {
+			return nil, err
+		}
+		left = &exprOr{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (s *exprParser) and() (columnExpr, error) {
+	left, err := s.unary()
+	if err != nil {
+		return nil, err
+	}
+	for s.peek() == "&&" {
+		s.next()
+		right, err := s.unary()
+		if err != nil {
+			return nil, err
+		}
+		left = &exprAnd{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (s *exprParser) unary() (columnExpr, error) {
+	token := s.next()
+	switch token {
+	case "":
+		return nil, fmt.Errorf("unexpected end")
+	case "!":
+		expr, err := s.unary()
+		if err != nil {
+			return nil, err
+		}
+		return &exprNot{expr: expr}, nil
+	case "(":
+		expr, err := s.or()
+		if err != nil {
+			return nil, err
+		}
+		if s.next() != ")" {
+			return nil, fmt.Errorf("missing )")
+		}
+		return expr, nil
+	}
+	field, ok := exprFields[token]
+	if !ok {
+		return nil, fmt.Errorf("unknown field %s", token)
+	}
+	op := s.peek()
+	switch op {
+	case "==", "!=", "=~", "!~", "<", "<=", ">", ">=":
+		s.next()
+	default:
+		if field.kind != exprKindBool {
+			return nil, fmt.Errorf("%s field %s is not compared", field.kind, token)
+		}
+		return &exprField{field: token}, nil
+	}
+	value, kind, err := exprValue(s.next())
+	if err != nil {
+		return nil, err
+	}
+	if kind != field.kind {
+		return nil, fmt.Errorf("%s field %s is compared with the %s %v", field.kind, token, kind, value)
+	}
+	compare := &exprCompare{field: token, op: op, value: value}
+	switch op {
+	case "=~", "!~":
+		if kind != exprKindString {
+			return nil, fmt.Errorf("%s requires a string field, %s is %s", op, token, kind)
+		}
+		if compare.regexp, err = regexp.Compile(value.(string)); err != nil {
+			return nil, err
+		}
+	case "<", "<=", ">", ">=":
+		if kind != exprKindInt {
+			return nil, fmt.Errorf("%s requires an int field, %s is %s", op, token, kind)
+		}
+	}
+	return compare, nil
+}
+
+// exprValue The value of the literal: quoted string, integer, true or false.
+func exprValue(token string) (any, string, error) {
+	switch {
+	case token == "":
+		return nil, "", fmt.Errorf("missing value")
+	case token == "true" || token == "false":
+		return token == "true", exprKindBool, nil
+	case token[0] == '\'' || token[0] == '"':
+		value := token[1 : len(token)-1]
+		value = strings.ReplaceAll(value, `\`+token[:1], token[:1])
+		return value, exprKindString, nil
+	}
+	value, err := strconv.Atoi(token)
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid value %s", token)
+	}
+	return value, exprKindInt, nil
+}
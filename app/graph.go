@@ -0,0 +1,65 @@
+package app
+
+import (
+	"encoding/json"
+)
+
+// Graph Table dependency graph output by the graph command, the tables are the nodes and the foreign keys are the edges.
+type Graph struct {
+	Nodes  []*GraphNode `json:"nodes"`
+	Edges  []*GraphEdge `json:"edges"`
+	Cycles [][]string   `json:"cycles,omitempty"` // table names of each foreign key cycle
+}
+
+// GraphNode A table of the dependency graph.
+type GraphNode struct {
+	Table   string `json:"table"`             // table name
+	Comment string `json:"comment,omitempty"` // table comment
+	Weight  int    `json:"weight"`            // number of columns of the table
+}
+
+// GraphEdge A foreign key of the dependency graph, from the referencing table to the referenced table.
+type GraphEdge struct {
+	Name              string   `json:"name,omitempty"`          // foreign key constraint name, empty for SQLite
+	From              string   `json:"from"`                    // referencing table
+	To                string   `json:"to"`                      // referenced table
+	Columns           []string `json:"columns"`                 // local columns
+	ReferencedColumns []string `json:"referenced_columns"`      // referenced columns
+	Weight            int      `json:"weight"`                  // number of columns of the foreign key
+	External          bool     `json:"external,omitempty"`      // the referenced table is not exported, it is not a node of the graph
+	PartOfCycle       bool     `json:"part_of_cycle,omitempty"` // both tables belong to the same foreign key cycle
+}
+
+// tableGraph The dependency graph of the tables, the nodes are in the topological order so that referenced tables come first.
+func tableGraph(tmp *Template) *Graph {
+	graph := &Graph{
+		Nodes:  make([]*GraphNode, 0, len(tmp.Tables)),
+		Edges:  make([]*GraphEdge, 0),
+		Cycles: tmp.TableCycles,
+	}
+	for _, table := range tmp.TablesTopological {
+		graph.Nodes = append(graph.Nodes, &GraphNode{
+			Table:   table.Table,
+			Comment: table.Comment,
+			Weight:  len(table.Columns),
+		})
+		for _, relation := range table.Relations {
+			graph.Edges = append(graph.Edges, &GraphEdge{
+				Name:              relation.ForeignKey.Name,
+				From:              table.Table,
+				To:                relation.ForeignKey.ReferencedTable,
+				Columns:           relation.ForeignKey.Columns,
+				ReferencedColumns: relation.ForeignKey.ReferencedColumns,
+				Weight:            len(relation.ForeignKey.Columns),
+				External:          relation.ReferencedTable == nil,
+				PartOfCycle:       relation.PartOfCycle,
+			})
+		}
+	}
+	return graph
+}
+
+// graphJSON The dependency graph of the tables as indented JSON.
+func graphJSON(tmp *Template) ([]byte, error) {
+	return json.MarshalIndent(tableGraph(tmp), "", "\t")
+}
@@ -0,0 +1,109 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"go/token"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// TableGroup A group of tables generated to its own directory and go package by the up command jobs with group enabled.
+type TableGroup struct {
+	Name    string   `yaml:"name"`    // group name, such as billing
+	Tables  []string `yaml:"tables"`  // table names or regular expressions (^...$)
+	Dir     string   `yaml:"dir"`     // output directory, the output of the jobs is written to it
+	Package string   `yaml:"package"` // go package name, the base name of the directory if not set
+
+	names   map[string]*struct{}
+	regexps []*regexp.Regexp
+}
+
+// packageName The configured package name or the base name of the directory.
+func (s *TableGroup) packageName() string {
+	if s.Package != "" {
+		return s.Package
+	}
+	return strings.ReplaceAll(filepath.Base(filepath.Clean(s.Dir)), "-", "_")
+}
+
+// validate The directory must be set and the package name must be a go identifier.
+func (s *TableGroup) validate() error {
+	if s.Dir == "" {
+		return fmt.Errorf("group %s: dir is not set", s.Name)
+	}
+	if name := s.packageName(); !token.IsIdentifier(name) {
+		return fmt.Errorf("group %s: invalid package name: %s", s.Name, name)
+	}
+	return nil
+}
+
+// match Whether the table belongs to the group.
+func (s *TableGroup) match(table string) bool {
+	if _, ok := s.names[table]; ok {
+		return true
+	}
+	for _, v := range s.regexps {
+		if v.MatchString(table) {
+			return true
+		}
+	}
+	return false
+}
+
+// initConfigGroups Configuration Initialization
+func initConfigGroups(cfg *Config) {
+	for _, group := range cfg.Groups {
+		group.names = make(map[string]*struct{})
+		group.regexps = nil
+		for _, v := range group.Tables {
+			v = strings.TrimSpace(v)
+			if strings.HasPrefix(v, "^") && strings.HasSuffix(v, "$") {
+				group.regexps = append(group.regexps, regexp.MustCompile(v))
+				continue
+			}
+			group.names[v] = nil
+		}
+	}
+}
+
+// WithGroup Only export the tables of the named group.
+func WithGroup(name string) Option {
+	return func(cfg *Config) {
+		cfg.groupName = name
+	}
+}
+
+// configGroup The group selected by WithGroup, nil if no group is selected.
+func configGroup(cfg *Config) (*TableGroup, error) {
+	if cfg.groupName == "" {
+		return nil, nil
+	}
+	for _, group := range cfg.Groups {
+		if group.Name == cfg.groupName {
+			return group, nil
+		}
+	}
+	return nil, fmt.Errorf("group %s is not configured", cfg.groupName)
+}
+
+// groupDoc The path and the content of the doc.go file of the group directory, formatted with the line endings of the configuration.
+func groupDoc(cfg *Config, group *TableGroup) (string, []byte) {
+	name := group.packageName()
+	content := fmt.Sprintf("// Package %s Generated code of the %s tables.\npackage %s\n", name, group.Name, name)
+	return filepath.Join(group.Dir, "doc.go"), formatOutput(cfg, []byte(content))
+}
+
+// writeGroupDoc Create the doc.go file of the group directory, an existing doc.go file is kept.
+func writeGroupDoc(cfg *Config, group *TableGroup) error {
+	file, content := groupDoc(cfg, group)
+	if _, err := os.Stat(file); err == nil || !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	if err := os.MkdirAll(group.Dir, 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(file, content, 0o644)
+}
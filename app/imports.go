@@ -0,0 +1,62 @@
+package app
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// importsPlaceholder Output of renderImports, replaced with the import block after the template is executed.
+const importsPlaceholder = "\x00pts:imports\x00"
+
+// importCollector Import paths added by the addImport template function while the template is executed.
+type importCollector struct {
+	paths []string
+}
+
+// add Add import paths, a value is an import path or a list of import paths such as .Imports; the output is empty.
+func (s *importCollector) add(values ...any) (string, error) {
+	for _, value := range values {
+		var paths []string
+		switch v := value.(type) {
+		case string:
+			paths = []string{v}
+		case []string:
+			paths = v
+		default:
+			return "", fmt.Errorf("addImport: unsupported value type %T", value)
+		}
+		for _, path := range paths {
+			path = strings.TrimSpace(path)
+			if path != "" && !slices.Contains(s.paths, path) {
+				s.paths = append(s.paths, path)
+			}
+		}
+	}
+	return "", nil
+}
+
+// render The import block is only known after the template is executed, a placeholder is output instead.
+func (s *importCollector) render() string {
+	return importsPlaceholder
+}
+
+// replace Replace the placeholders with the sorted import block, empty if no import is added.
+func (s *importCollector) replace(content []byte) []byte {
+	if !bytes.Contains(content, []byte(importsPlaceholder)) {
+		return content
+	}
+	block := ""
+	if len(s.paths) > 0 {
+		paths := slices.Clone(s.paths)
+		slices.Sort(paths)
+		lines := make([]string, 0, len(paths))
+		for _, path := range paths {
+			lines = append(lines, "\t"+strconv.Quote(path))
+		}
+		block = "import (\n" + strings.Join(lines, "\n") + "\n)\n"
+	}
+	return bytes.ReplaceAll(content, []byte(importsPlaceholder), []byte(block))
+}
@@ -0,0 +1,68 @@
+package app
+
+import (
+	"strings"
+)
+
+// charsetBytes Maximum bytes of a character of the MySQL character sets.
+var charsetBytes = map[string]int{
+	"utf8mb4": 4,
+	"utf8mb3": 3,
+	"utf8":    3, // alias of utf8mb3
+	"utf16":   4,
+	"utf16le": 4,
+	"utf32":   4,
+	"ucs2":    2,
+	"gb18030": 4,
+	"gbk":     2,
+	"gb2312":  2,
+	"big5":    2,
+	"sjis":    2,
+	"cp932":   2,
+	"euckr":   2,
+	"ujis":    3,
+	"eucjpms": 3,
+	"latin1":  1,
+	"latin2":  1,
+	"ascii":   1,
+	"binary":  1,
+}
+
+// byteLimitedTypes MySQL types whose maximum length is in bytes whatever the character set, TEXT is at most 65535 bytes.
+var byteLimitedTypes = map[string]*struct{}{
+	"tinytext":   nil,
+	"text":       nil,
+	"mediumtext": nil,
+	"longtext":   nil,
+}
+
+// charsetMaxBytes Maximum bytes of a character of the column, from the character set or the character set prefix of the collation; 0 if unknown.
+func (s *Column) charsetMaxBytes() int {
+	charset := ""
+	if s.CharacterSetName != nil {
+		charset = *s.CharacterSetName
+	} else if s.CollationName != nil {
+		// utf8mb4_0900_ai_ci => utf8mb4
+		charset, _, _ = strings.Cut(*s.CollationName, "_")
+	}
+	return charsetBytes[strings.ToLower(charset)]
+}
+
+// initMaxLength The maximum characters and bytes of the column value.
+// varchar(255) utf8mb4 => 255 characters, 1020 bytes; text utf8mb4 => 16383 characters (65535 / 4), 65535 bytes.
+func (s *Column) initMaxLength() {
+	s.MaxChars, s.MaxBytes = 0, 0
+	chars, bytes := exprInt(s.CharacterMaximumLength), exprInt(s.CharacterOctetLength)
+	multiplier := s.charsetMaxBytes()
+	if _, ok := byteLimitedTypes[s.dataType()]; ok && bytes > 0 {
+		s.MaxBytes = bytes
+		if multiplier > 0 {
+			s.MaxChars = bytes / multiplier
+		}
+		return
+	}
+	s.MaxChars, s.MaxBytes = max(chars, 0), max(bytes, 0)
+	if s.MaxBytes == 0 && s.MaxChars > 0 && multiplier > 0 {
+		s.MaxBytes = s.MaxChars * multiplier
+	}
+}
@@ -0,0 +1,46 @@
+package app
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// lint Report the problems of the exported tables, one problem per line.
+func lint(cfg *Config, tmp *Template) []byte {
+	buf := bytes.NewBuffer(nil)
+	for _, line := range slices.Concat(lintTenant(cfg, tmp.Tables), lintCurrency(tmp.Tables)) {
+		buf.WriteString(line)
+		buf.WriteString("\n")
+	}
+	return buf.Bytes()
+}
+
+// lintTenant Tables missing the tenant column, except the excluded tables.
+func lintTenant(cfg *Config, tables []*Table) []string {
+	if cfg.Tenant.Column == "" {
+		return nil
+	}
+	result := make([]string, 0)
+	for _, t := range tables {
+		if t.TenantColumn != "" || slices.Contains(cfg.Tenant.Exclude, t.Table) {
+			continue
+		}
+		result = append(result, fmt.Sprintf("tenant: table %s does not have the tenant column %s", t.Table, cfg.Tenant.Column))
+	}
+	return result
+}
+
+// lintCurrency Currency columns with a floating point go type, the amounts are not exact.
+func lintCurrency(tables []*Table) []string {
+	result := make([]string, 0)
+	for _, t := range tables {
+		for _, c := range t.Columns {
+			if c.Currency && strings.HasPrefix(strings.TrimPrefix(c.GoType, "*"), "float") {
+				result = append(result, fmt.Sprintf("currency: column %s.%s uses the floating point type %s, configure currency.type", t.Table, c.Column, c.GoType))
+			}
+		}
+	}
+	return result
+}
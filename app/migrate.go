@@ -0,0 +1,316 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/cd365/hey/v7/cst"
+)
+
+const (
+	MigrateFormatGolangMigrate = "golang-migrate" // {version}_{name}.up.sql and {version}_{name}.down.sql
+	MigrateFormatGoose         = "goose"          // {version}_{name}.sql with the -- +goose Up and -- +goose Down sections
+)
+
+// Migration A migration of the initial migration set.
+type Migration struct {
+	Version int    // sequence number starting from 1
+	Name    string // create_user_table, add_foreign_keys
+	Up      string // statements applying the migration
+	Down    string // statements reverting the migration
+}
+
+const (
+	gooseUp   = "-- +goose Up"   // annotation of the statements applying a goose migration
+	gooseDown = "-- +goose Down" // annotation of the statements reverting a goose migration
+)
+
+// migrationNameReplace Characters not allowed in the migration file names.
+var migrationNameReplace = regexp.MustCompile(`[^a-z0-9_]+`)
+
+// migrationLiteral A literal SQLite column default: a number, a string, a blob, NULL, TRUE, FALSE or CURRENT_TIME, CURRENT_DATE, CURRENT_TIMESTAMP.
+var migrationLiteral = regexp.MustCompile(`(?i)^(?:[+-]?\d+(?:\.\d+)?(?:e[+-]?\d+)?|'(?:[^']|'')*'|x'[0-9a-f]*'|null|true|false|current_time|current_date|current_timestamp)$`)
+
+// migrationDialect The DDL dialect of the migrations, CockroachDB and Greenplum use the PostgreSQL DDL.
+func migrationDialect(dialect string) string {
+	switch dialect {
+	case DriverCockroach, DriverGreenplum:
+		return string(cst.Postgresql)
+	}
+	return dialect
+}
+
+// migrationForeignKey The FOREIGN KEY clause of the foreign key, NO ACTION is omitted.
+func migrationForeignKey(dialect string, foreignKey *ForeignKey) string {
+	quote := func(names []string) string {
+		quoted := make([]string, 0, len(names))
+		for _, name := range names {
+			quoted = append(quoted, quoteIdentifier(dialect, name))
+		}
+		return strings.Join(quoted, ", ")
+	}
+	clause := fmt.Sprintf("FOREIGN KEY (%s) REFERENCES %s", quote(foreignKey.Columns), quoteIdentifier(dialect, foreignKey.ReferencedTable))
+	// The referenced columns of SQLite are empty when the referenced table has no primary key of the foreign key columns
+	if !slices.Contains(foreignKey.ReferencedColumns, "") {
+		clause += fmt.Sprintf(" (%s)", quote(foreignKey.ReferencedColumns))
+	}
+	if rule := strings.ToUpper(foreignKey.OnDelete); rule != "" && rule != "NO ACTION" {
+		clause += " ON DELETE " + rule
+	}
+	if rule := strings.ToUpper(foreignKey.OnUpdate); rule != "" && rule != "NO ACTION" {
+		clause += " ON UPDATE " + rule
+	}
+	return clause
+}
+
+// migrationForeignKeyName The constraint name of the foreign key, fk_{table}_{columns} if the database does not name it.
+func migrationForeignKeyName(table *Table, foreignKey *ForeignKey) string {
+	if foreignKey.Name != "" {
+		return foreignKey.Name
+	}
+	return fmt.Sprintf("fk_%s_%s", table.Table, strings.Join(foreignKey.Columns, "_"))
+}
+
+// migrationDefault The DEFAULT clause of the column, empty if the column has no default or is the auto increment column.
+// The PostgreSQL and SQLite defaults are expressions, the MySQL defaults are values unless they are generated.
+func migrationDefault(dialect string, table *Table, column *Column) string {
+	if column.ColumnDefault == nil || column.Column == table.AutoIncrementColumn {
+		return ""
+	}
+	value := *column.ColumnDefault
+	switch dialect {
+	case string(cst.Mysql):
+		extra := ""
+		if column.Extra != nil {
+			extra = strings.ToUpper(*column.Extra)
+		}
+		switch {
+		case strings.HasPrefix(strings.ToUpper(value), "CURRENT_TIMESTAMP"):
+		case strings.Contains(extra, "DEFAULT_GENERATED"):
+			// The expression defaults of MySQL 8.0.13 are written in parentheses, such as (uuid())
+			value = "(" + value + ")"
+		default:
+			value = "'" + strings.ReplaceAll(value, "'", "''") + "'"
+		}
+	case string(cst.Sqlite):
+		// The literals are written as they are, the other expressions in parentheses, such as (datetime('now'))
+		if !migrationLiteral.MatchString(value) && !strings.HasPrefix(value, "(") {
+			value = "(" + value + ")"
+		}
+	}
+	return " DEFAULT " + value
+}
+
+// migrationCreateTable The CREATE TABLE and CREATE INDEX statements of the table and the foreign keys referencing tables created later,
+// which are only known in a foreign key cycle; SQLite does not check the referenced tables, all foreign keys are in CREATE TABLE.
+func migrationCreateTable(dialect string, table *Table, created map[string]*struct{}, exported map[string]*struct{}) (string, []*ForeignKey, error) {
+	quote := func(names []string) string {
+		quoted := make([]string, 0, len(names))
+		for _, name := range names {
+			quoted = append(quoted, quoteIdentifier(dialect, name))
+		}
+		return strings.Join(quoted, ", ")
+	}
+	sqlite := dialect == string(cst.Sqlite)
+	var primary *Index
+	for _, index := range table.Indexes {
+		if index.Primary {
+			primary = index
+		}
+	}
+	// INTEGER PRIMARY KEY is the rowid, it is assigned automatically
+	rowid := sqlite && primary != nil && len(primary.Columns) == 1 && primary.Columns[0] == table.AutoIncrementColumn
+	lines := make([]string, 0, len(table.Columns)+len(table.ForeignKeys)+1)
+	for _, column := range table.Columns {
+		columnType, err := sqlType(dialect, column)
+		if err != nil {
+			return "", nil, err
+		}
+		// The MySQL column type and the SQLite declared type are the DDL of the column type, such as int unsigned, enum('a','b')
+		if (dialect == string(cst.Mysql) || sqlite) && column.Type != nil && *column.Type != "" {
+			columnType = *column.Type
+		}
+		if rowid && column.Column == table.AutoIncrementColumn {
+			lines = append(lines, fmt.Sprintf("%s INTEGER PRIMARY KEY AUTOINCREMENT", quoteIdentifier(dialect, column.Column)))
+			continue
+		}
+		line := fmt.Sprintf("%s %s", quoteIdentifier(dialect, column.Column), columnType)
+		if !column.nullable() {
+			line += " NOT NULL"
+		}
+		line += migrationDefault(dialect, table, column)
+		if column.Column == table.AutoIncrementColumn {
+			switch dialect {
+			case string(cst.Postgresql):
+				line += " GENERATED BY DEFAULT AS IDENTITY"
+			case string(cst.Mysql):
+				line += " AUTO_INCREMENT"
+			}
+		}
+		lines = append(lines, line)
+	}
+	if primary != nil && !rowid {
+		lines = append(lines, fmt.Sprintf("PRIMARY KEY (%s)", quote(primary.Columns)))
+	}
+	deferred := make([]*ForeignKey, 0)
+	for _, foreignKey := range table.ForeignKeys {
+		if _, ok := exported[foreignKey.ReferencedTable]; !ok {
+			continue
+		}
+		if _, ok := created[foreignKey.ReferencedTable]; !ok && !sqlite && foreignKey.ReferencedTable != table.Table {
+			deferred = append(deferred, foreignKey)
+			continue
+		}
+		line := migrationForeignKey(dialect, foreignKey)
+		if foreignKey.Name != "" {
+			line = fmt.Sprintf("CONSTRAINT %s %s", quoteIdentifier(dialect, foreignKey.Name), line)
+		}
+		lines = append(lines, line)
+	}
+	buf := &strings.Builder{}
+	buf.WriteString(fmt.Sprintf("CREATE TABLE %s (\n\t%s\n);\n", quoteIdentifier(dialect, table.Table), strings.Join(lines, ",\n\t")))
+	for _, index := range table.Indexes {
+		if index.Primary || len(index.Columns) == 0 {
+			continue
+		}
+		unique := ""
+		if index.Unique {
+			unique = "UNIQUE "
+		}
+		// FULLTEXT and SPATIAL indexes of MySQL are never unique
+		if dialect == string(cst.Mysql) && (index.Method == "fulltext" || index.Method == "spatial") {
+			unique = strings.ToUpper(index.Method) + " "
+		}
+		// sqlite_ names are reserved, they are the indexes of the UNIQUE constraints
+		name := index.Name
+		if strings.HasPrefix(name, "sqlite_") {
+			name = fmt.Sprintf("%s_%s", table.Table, index.Name)
+		}
+		buf.WriteString(fmt.Sprintf("CREATE %s%s;\n", unique, migrationIndex(dialect, table, name, index, quote(index.Columns))))
+	}
+	return buf.String(), deferred, nil
+}
+
+// migrationIndex The CREATE INDEX statement after CREATE [UNIQUE], with the access method of PostgreSQL and the predicate of a partial index.
+func migrationIndex(dialect string, table *Table, name string, index *Index, columns string) string {
+	statement := fmt.Sprintf("INDEX %s ON %s", quoteIdentifier(dialect, name), quoteIdentifier(dialect, table.Table))
+	if dialect == string(cst.Postgresql) && index.Method != "" && index.Method != "btree" {
+		statement += " USING " + index.Method
+	}
+	statement += fmt.Sprintf(" (%s)", columns)
+	if index.Where != "" && dialect != string(cst.Mysql) {
+		statement += " WHERE " + index.Where
+	}
+	return statement
+}
+
+// migrations The initial migration set of the exported tables: a migration per table, referenced tables come before referencing tables,
+// and a last migration adding the foreign keys of the tables in foreign key cycles.
+// Check constraints and expression indexes are not included, the expressions are not read from the database.
+func migrations(tmp *Template) ([]*Migration, error) {
+	dialect := migrationDialect(tmp.Dialect)
+	exported := make(map[string]*struct{}, len(tmp.Tables))
+	for _, table := range tmp.Tables {
+		exported[table.Table] = nil
+	}
+	created := make(map[string]*struct{}, len(tmp.Tables))
+	result := make([]*Migration, 0, len(tmp.TablesTopological)+1)
+	up, drops := &strings.Builder{}, make([]string, 0)
+	for _, table := range tmp.TablesTopological {
+		create, deferred, err := migrationCreateTable(dialect, table, created, exported)
+		if err != nil {
+			return nil, fmt.Errorf("migrate: table %s: %w", table.Table, err)
+		}
+		created[table.Table] = nil
+		result = append(result, &Migration{
+			Version: len(result) + 1,
+			Name:    fmt.Sprintf("create_%s_table", table.Table),
+			Up:      create,
+			Down:    fmt.Sprintf("DROP TABLE %s;\n", quoteIdentifier(dialect, table.Table)),
+		})
+		for _, foreignKey := range deferred {
+			name := quoteIdentifier(dialect, migrationForeignKeyName(table, foreignKey))
+			up.WriteString(fmt.Sprintf("ALTER TABLE %s ADD CONSTRAINT %s %s;\n", quoteIdentifier(dialect, table.Table), name, migrationForeignKey(dialect, foreignKey)))
+			drop := fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT %s;\n", quoteIdentifier(dialect, table.Table), name)
+			if dialect == string(cst.Mysql) {
+				drop = fmt.Sprintf("ALTER TABLE %s DROP FOREIGN KEY %s;\n", quoteIdentifier(dialect, table.Table), name)
+			}
+			drops = append(drops, drop)
+		}
+	}
+	if up.Len() > 0 {
+		// The constraints are dropped in the reverse order
+		slices.Reverse(drops)
+		result = append(result, &Migration{
+			Version: len(result) + 1,
+			Name:    "add_foreign_keys",
+			Up:      up.String(),
+			Down:    strings.Join(drops, ""),
+		})
+	}
+	return result, nil
+}
+
+// migrationFiles The file names and contents of the migrations in the format, in the order of the versions.
+func migrationFiles(format string, list []*Migration) ([][2]string, error) {
+	files := make([][2]string, 0, len(list)*2)
+	for _, migration := range list {
+		name := migrationNameReplace.ReplaceAllString(strings.ToLower(migration.Name), "_")
+		switch format {
+		case MigrateFormatGolangMigrate, "":
+			// The default number of digits of migrate create -seq
+			prefix := fmt.Sprintf("%06d_%s", migration.Version, name)
+			files = append(files, [2]string{prefix + ".up.sql", migration.Up}, [2]string{prefix + ".down.sql", migration.Down})
+		case MigrateFormatGoose:
+			// The default number of digits of goose create -s
+			content := fmt.Sprintf("%s\n%s\n%s\n%s", gooseUp, migration.Up, gooseDown, migration.Down)
+			files = append(files, [2]string{fmt.Sprintf("%05d_%s.sql", migration.Version, name), content})
+		default:
+			return nil, fmt.Errorf("migrate: unsupported format %s, supported formats: %s, %s", format, MigrateFormatGolangMigrate, MigrateFormatGoose)
+		}
+	}
+	return files, nil
+}
+
+// Migrate Write the initial migration set of the exported tables to the directory in the format: golang-migrate, goose.
+// The directory must not contain any migration file of the set, the written file names are returned one per line.
+func (s *App) Migrate(ctx context.Context, dir string, format string) ([]byte, error) {
+	if dir == "" {
+		return nil, fmt.Errorf("migrate: the migration directory is empty")
+	}
+	return s.Run(ctx, func(ctx context.Context, tmp *Template) ([]byte, error) {
+		list, err := migrations(tmp)
+		if err != nil {
+			return nil, err
+		}
+		files, err := migrationFiles(format, list)
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			if _, err = os.Stat(filepath.Join(dir, file[0])); err == nil {
+				return nil, fmt.Errorf("migrate: migration %s already exists", filepath.Join(dir, file[0]))
+			} else if !errors.Is(err, os.ErrNotExist) {
+				return nil, err
+			}
+		}
+		if err = os.MkdirAll(dir, 0o755); err != nil {
+			return nil, err
+		}
+		written := &strings.Builder{}
+		for _, file := range files {
+			path := filepath.Join(dir, file[0])
+			if err = os.WriteFile(path, formatOutput(s.cfg, []byte(file[1])), 0o644); err != nil {
+				return nil, err
+			}
+			written.WriteString(path + "\n")
+		}
+		return []byte(written.String()), nil
+	})
+}
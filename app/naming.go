@@ -0,0 +1,150 @@
+package app
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+const (
+	NamingGoDefault         = "go-default"
+	NamingStrictInitialisms = "strict-initialisms"
+)
+
+// NamingStrategy Names of the table types, column fields and generated methods, the name is a table or column name such as user_id.
+type NamingStrategy interface {
+	Pascal(name string) string    // exported go name, user_id => UserId | UserID
+	Camel(name string) string     // unexported go name, user_id => userId | userID
+	Underline(name string) string // snake case name, UserID => user_i_d | user_id
+}
+
+// namingStrategies Naming strategies of naming.strategy, programs embedding pts add strategies by RegisterNamingStrategy.
+var namingStrategies = map[string]func(cfg *Config) NamingStrategy{
+	NamingGoDefault: func(cfg *Config) NamingStrategy {
+		return goDefaultNaming{}
+	},
+	NamingStrictInitialisms: func(cfg *Config) NamingStrategy {
+		return newStrictInitialismsNaming(cfg.Naming.Initialisms)
+	},
+}
+
+// RegisterNamingStrategy Add the naming strategy selected by naming.strategy, such as the naming rules of an organization.
+func RegisterNamingStrategy(name string, strategy func(cfg *Config) NamingStrategy) {
+	namingStrategies[name] = strategy
+}
+
+// initConfigNaming Configuration Initialization
+func initConfigNaming(cfg *Config) error {
+	name := cfg.Naming.Strategy
+	if name == "" {
+		name = NamingGoDefault
+	}
+	strategy, ok := namingStrategies[name]
+	if !ok {
+		return fmt.Errorf("naming: unknown strategy: %s", name)
+	}
+	cfg.naming = strategy(cfg)
+	return nil
+}
+
+// configNaming The naming strategy of the configuration, go-default if the configuration is not initialized.
+func configNaming(cfg *Config) NamingStrategy {
+	if cfg == nil || cfg.naming == nil {
+		return goDefaultNaming{}
+	}
+	return cfg.naming
+}
+
+// goDefaultNaming The words of the name are capitalized, id => Id.
+type goDefaultNaming struct{}
+
+func (goDefaultNaming) Pascal(name string) string {
+	return Pascal(name)
+}
+
+func (goDefaultNaming) Camel(name string) string {
+	return Camel(name)
+}
+
+func (goDefaultNaming) Underline(name string) string {
+	return Underline(name)
+}
+
+// commonInitialisms Initialisms of strict-initialisms, the go lint list.
+var commonInitialisms = []string{
+	"ACL", "API", "ASCII", "CPU", "CSS", "DNS", "EOF", "GUID", "HTML", "HTTP", "HTTPS", "ID", "IP", "JSON", "LHS",
+	"QPS", "RAM", "RHS", "RPC", "SLA", "SMTP", "SQL", "SSH", "TCP", "TLS", "TTL", "UDP", "UI", "UID", "UUID", "URI",
+	"URL", "UTF8", "VM", "XML", "XMPP", "XSRF", "XSS",
+}
+
+// strictInitialismsNaming The initialism words of the name are upper case, user_id => UserID, id_card => idCard.
+type strictInitialismsNaming struct {
+	initialisms map[string]*struct{}
+}
+
+func newStrictInitialismsNaming(initialisms []string) *strictInitialismsNaming {
+	s := &strictInitialismsNaming{
+		initialisms: make(map[string]*struct{}, len(commonInitialisms)+len(initialisms)),
+	}
+	for _, v := range slices.Concat(commonInitialisms, initialisms) {
+		s.initialisms[strings.ToUpper(strings.TrimSpace(v))] = nil
+	}
+	return s
+}
+
+func (s *strictInitialismsNaming) initialism(word string) bool {
+	_, ok := s.initialisms[strings.ToUpper(word)]
+	return ok
+}
+
+func (s *strictInitialismsNaming) Pascal(name string) string {
+	words := strings.Split(name, "_")
+	for i, word := range words {
+		if s.initialism(word) {
+			words[i] = strings.ToUpper(word)
+		} else {
+			words[i] = Pascal(word)
+		}
+	}
+	return strings.Join(words, "")
+}
+
+func (s *strictInitialismsNaming) Camel(name string) string {
+	words := strings.Split(name, "_")
+	first := true
+	for i, word := range words {
+		switch {
+		case word == "":
+		case first && s.initialism(word):
+			// The first word is the lower case initialism, such as id_card => idCard
+			words[i] = strings.ToLower(word)
+			first = false
+		case first:
+			words[i] = Camel(word)
+			first = false
+		case s.initialism(word):
+			words[i] = strings.ToUpper(word)
+		default:
+			words[i] = Pascal(word)
+		}
+	}
+	return strings.Join(words, "")
+}
+
+// Underline The upper case initialisms are a single word, UserID => user_id, HTTPServer => http_server.
+func (s *strictInitialismsNaming) Underline(name string) string {
+	lower := func(c byte) bool { return c >= 'a' && c <= 'z' || c >= '0' && c <= '9' }
+	upper := func(c byte) bool { return c >= 'A' && c <= 'Z' }
+	tmp := make([]byte, 0, len(name)+4)
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		if upper(c) {
+			if i > 0 && (lower(name[i-1]) || upper(name[i-1]) && i+1 < len(name) && name[i+1] >= 'a' && name[i+1] <= 'z') {
+				tmp = append(tmp, '_')
+			}
+			c += 32
+		}
+		tmp = append(tmp, c)
+	}
+	return string(tmp)
+}
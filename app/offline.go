@@ -0,0 +1,119 @@
+package app
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/cd365/hey/v7"
+)
+
+// offlineDriverName The database/sql driver used in offline mode, every query returns no rows and nothing is sent to a database.
+const offlineDriverName = "pts-offline"
+
+func init() {
+	sql.Register(offlineDriverName, offlineDriver{})
+}
+
+type offlineDriver struct{}
+
+func (offlineDriver) Open(name string) (driver.Conn, error) {
+	return offlineConn{}, nil
+}
+
+type offlineConn struct{}
+
+func (offlineConn) Prepare(query string) (driver.Stmt, error) {
+	return offlineStmt{}, nil
+}
+
+func (offlineConn) Close() error {
+	return nil
+}
+
+func (offlineConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("transactions are not supported in offline mode")
+}
+
+type offlineStmt struct{}
+
+func (offlineStmt) Close() error {
+	return nil
+}
+
+func (offlineStmt) NumInput() int {
+	return -1
+}
+
+func (offlineStmt) Exec(args []driver.Value) (driver.Result, error) {
+	return driver.RowsAffected(0), nil
+}
+
+func (offlineStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return offlineRows{}, nil
+}
+
+type offlineRows struct{}
+
+func (offlineRows) Columns() []string {
+	return nil
+}
+
+func (offlineRows) Close() error {
+	return nil
+}
+
+func (offlineRows) Next(dest []driver.Value) error {
+	return io.EOF
+}
+
+// queryPrinter Print the SQL statements executed through hey.Way with the bound arguments when print_queries is enabled.
+type queryPrinter struct {
+	cfg *Config
+}
+
+func (s *queryPrinter) Track(ctx context.Context, track any) {
+	tracked, ok := track.(*hey.MyTrack)
+	if !ok || tracked.Type != hey.TrackSQL {
+		return
+	}
+	printQuery(s.cfg, tracked.Script)
+}
+
+// printQuery Print the SQL statement to the standard error when print_queries is enabled.
+func printQuery(cfg *Config, script string) {
+	if !cfg.PrintQueries {
+		return
+	}
+	_, _ = fmt.Fprintf(os.Stderr, "%s;\n", strings.TrimRight(script, "; \t\r\n"))
+}
+
+// NewOfflineApp Create an App that does not connect to the database, the metadata queries are executed by a driver that returns no rows.
+// The tables to query are the only_table tables, use it with print_queries to review the queries.
+func NewOfflineApp(config string, options ...Option) (app *App, err error) {
+	cfg, err := loadConfig(config, options)
+	if err != nil {
+		return
+	}
+	cfg.Offline = true
+	if err = initConfig(cfg); err != nil {
+		return
+	}
+	way, err := NewWay(cfg)
+	if err != nil {
+		return
+	}
+	app = &App{
+		cfg:    cfg,
+		way:    way,
+		schema: NewSchema(way),
+		mutex:  &sync.Mutex{},
+	}
+	return
+}
@@ -0,0 +1,102 @@
+package app
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/cd365/hey/v7"
+	"github.com/cd365/hey/v7/cst"
+)
+
+// Ping Result of the ping command.
+type Ping struct {
+	Dialect       string        // database type of the connection
+	ServerVersion string        // version of the database server, empty if it is unknown
+	Schemas       []string      // schema names the user can access, the database names of MySQL and ClickHouse; empty for the generic driver
+	Elapsed       time.Duration // time from the first statement to the last result
+}
+
+// queryPingSchemas The statement querying the schema names the user can access, empty if the names are not queried.
+func queryPingSchemas(way *hey.Way) string {
+	switch way.Config().Manual.DatabaseType {
+	case cst.Mysql, DriverSnowflake:
+		return "SELECT schema_name FROM information_schema.schemata ORDER BY schema_name ASC"
+	case cst.Postgresql, DriverRedshift, DriverGreenplum, DriverCockroach:
+		return "SELECT nspname FROM pg_catalog.pg_namespace WHERE ( nspname NOT LIKE 'pg\\_%' AND nspname <> 'information_schema' AND has_schema_privilege(nspname, 'USAGE') ) ORDER BY nspname ASC"
+	case cst.Sqlite:
+		return "SELECT name FROM pragma_database_list ORDER BY seq ASC"
+	case DriverOracle:
+		return "SELECT DISTINCT owner FROM all_tables ORDER BY owner ASC"
+	case DriverDb2:
+		return "SELECT schemaname FROM syscat.schemata WHERE ( schemaname NOT LIKE 'SYS%' AND schemaname NOT IN ( 'NULLID', 'SQLJ' ) ) ORDER BY schemaname ASC"
+	case DriverHana:
+		return "SELECT schema_name FROM sys.schemas WHERE ( has_privileges = 'TRUE' ) ORDER BY schema_name ASC"
+	case DriverClickhouse:
+		return "SELECT name FROM system.databases ORDER BY name ASC"
+	case DriverSpanner:
+		// The default schema is the empty name
+		return "SELECT schema_name FROM information_schema.schemata WHERE ( schema_name NOT IN ( 'INFORMATION_SCHEMA', 'SPANNER_SYS' ) ) ORDER BY schema_name ASC"
+	case DriverDuckdb:
+		return "SELECT schema_name FROM duckdb_schemas() WHERE ( database_name = current_database() AND NOT internal ) ORDER BY schema_name ASC"
+	}
+	return ""
+}
+
+// Ping Open the connection and run SELECT 1, then query the server version and the accessible schema names; nothing is retried.
+// The tables are not queried, the table filters of the configuration are not used.
+func (s *App) Ping(ctx context.Context) (*Ping, error) {
+	if s.way == nil || s.cfg.Offline {
+		return nil, fmt.Errorf("ping: there is no database connection in demo and offline mode")
+	}
+	start := time.Now()
+	prepare := "SELECT 1"
+	switch s.way.Config().Manual.DatabaseType {
+	case DriverOracle:
+		prepare = "SELECT 1 FROM dual"
+	case DriverDb2:
+		prepare = "SELECT 1 FROM sysibm.sysdummy1"
+	case DriverHana:
+		prepare = "SELECT 1 FROM dummy"
+	case DriverFirebird:
+		prepare = "SELECT 1 FROM rdb$database"
+	}
+	one := 0
+	err := s.way.Query(ctx, hey.NewSQL(prepare), func(rows *sql.Rows) error {
+		for rows.Next() {
+			if err := rows.Scan(&one); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("ping: %w", err)
+	}
+	if err = initServer(ctx, s.cfg, s.way); err != nil {
+		return nil, fmt.Errorf("ping: server version: %w", err)
+	}
+	result := &Ping{
+		Dialect:       string(s.way.Config().Manual.DatabaseType),
+		ServerVersion: s.cfg.serverVersion,
+		Schemas:       make([]string, 0),
+	}
+	if prepare = queryPingSchemas(s.way); prepare != "" {
+		err = s.way.Query(ctx, hey.NewSQL(prepare), func(rows *sql.Rows) error {
+			for rows.Next() {
+				name := ""
+				if err := rows.Scan(&name); err != nil {
+					return err
+				}
+				result.Schemas = append(result.Schemas, name)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("ping: schemas: %w", err)
+		}
+	}
+	result.Elapsed = time.Since(start)
+	return result, nil
+}
@@ -0,0 +1,50 @@
+package app
+
+import (
+	"slices"
+)
+
+// positionGaps Ordinal positions between 1 and the last column position that no column has.
+func positionGaps(columns []*Column) []int {
+	positions := make(map[int]*struct{}, len(columns))
+	last := 0
+	for _, column := range columns {
+		if column.OrdinalPosition == nil {
+			continue
+		}
+		positions[*column.OrdinalPosition] = nil
+		last = max(last, *column.OrdinalPosition)
+	}
+	var gaps []int
+	for i := 1; i < last; i++ {
+		if _, ok := positions[i]; !ok {
+			gaps = append(gaps, i)
+		}
+	}
+	return gaps
+}
+
+// columnOrder Column names in the ordinal position order, the column order of the table if the positions are not known.
+func columnOrder(table *Table) []string {
+	columns := slices.Clone(table.Columns)
+	slices.SortStableFunc(columns, func(a, b *Column) int {
+		if a.OrdinalPosition == nil || b.OrdinalPosition == nil {
+			return 0
+		}
+		return *a.OrdinalPosition - *b.OrdinalPosition
+	})
+	names := make([]string, 0, len(columns))
+	for _, column := range columns {
+		names = append(names, column.Column)
+	}
+	return names
+}
+
+// columnsReordered Whether the columns of both tables are in a different relative order, added and removed columns are ignored.
+func columnsReordered(previous *Table, current *Table) bool {
+	before, after := columnOrder(previous), columnOrder(current)
+	common := func(names []string, other []string) []string {
+		return slices.DeleteFunc(names, func(name string) bool { return !slices.Contains(other, name) })
+	}
+	return !slices.Equal(common(slices.Clone(before), after), common(slices.Clone(after), before))
+}
@@ -0,0 +1,91 @@
+package app
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/cd365/hey/v7"
+	"github.com/cd365/hey/v7/cst"
+)
+
+// rawMetadataQuery The query of the metadata rows of the columns of the table and the result column holding the column name.
+func rawMetadataQuery(cfg *Config, dialect string, table *Table) (*hey.SQL, string) {
+	switch dialect {
+	case string(cst.Mysql), string(cst.Postgresql), DriverRedshift, DriverGreenplum, DriverCockroach, DriverSnowflake, DriverSpanner:
+		return hey.NewSQL("SELECT * FROM information_schema.columns WHERE ( table_schema = ? AND table_name = ? ) ORDER BY ordinal_position ASC", table.Database, table.Table), "column_name"
+	case string(cst.Sqlite):
+		return hey.NewSQL("SELECT * FROM pragma_table_xinfo(?) ORDER BY cid ASC", table.Table), "name"
+	case DriverOracle:
+		return hey.NewSQL("SELECT * FROM all_tab_columns WHERE ( owner = ? AND table_name = ? ) ORDER BY column_id ASC", table.Database, table.Table), "column_name"
+	case DriverDb2:
+		return hey.NewSQL("SELECT * FROM syscat.columns WHERE ( tabschema = ? AND tabname = ? ) ORDER BY colno ASC", table.Database, table.Table), "colname"
+	case DriverHana:
+		return hey.NewSQL("SELECT * FROM sys.table_columns WHERE ( schema_name = ? AND table_name = ? ) ORDER BY position ASC", table.Database, table.Table), "column_name"
+	case DriverFirebird:
+		// The names of the system tables are padded with spaces
+		return hey.NewSQL("SELECT rf.*, TRIM(rf.rdb$field_name) AS column_name FROM rdb$relation_fields rf WHERE ( rf.rdb$relation_name = ? ) ORDER BY rf.rdb$field_position ASC", table.Table), "column_name"
+	case DriverClickhouse:
+		return hey.NewSQL("SELECT * FROM system.columns WHERE ( database = ? AND table = ? ) ORDER BY position ASC", table.Database, table.Table), "name"
+	case DriverDuckdb:
+		return hey.NewSQL("SELECT * FROM duckdb_columns() WHERE ( database_name = current_database() AND schema_name = ? AND table_name = ? ) ORDER BY column_index ASC", table.Database, table.Table), "column_name"
+	case DriverBigquery:
+		return hey.NewSQL(fmt.Sprintf("SELECT * FROM `%s`.INFORMATION_SCHEMA.COLUMNS WHERE ( table_name = ? ) ORDER BY ordinal_position ASC", table.Database), table.Table), "column_name"
+	case DriverGeneric:
+		return hey.NewSQL(cfg.Database.Generic.QueryColumns, table.Database, table.Table), "column_name"
+	}
+	return nil, ""
+}
+
+// rawMetadataTables Populate the raw metadata of the columns when raw_metadata is enabled: every column of the metadata row of the column
+// as returned by the database, key is the lower case result column name, byte values are strings.
+func rawMetadataTables(ctx context.Context, cfg *Config, way *hey.Way, tables []*Table) error {
+	if !cfg.RawMetadata {
+		return nil
+	}
+	dialect := string(way.Config().Manual.DatabaseType)
+	for _, table := range tables {
+		query, key := rawMetadataQuery(cfg, dialect, table)
+		if query == nil {
+			return nil
+		}
+		columns := make(map[string]*Column, len(table.Columns))
+		for _, column := range table.Columns {
+			columns[column.Column] = column
+		}
+		err := way.Query(ctx, query, func(rows *sql.Rows) error {
+			names, err := rows.Columns()
+			if err != nil {
+				return err
+			}
+			for rows.Next() {
+				values := make([]any, len(names))
+				dest := make([]any, len(names))
+				for i := range values {
+					dest[i] = &values[i]
+				}
+				if err = rows.Scan(dest...); err != nil {
+					return err
+				}
+				raw := make(map[string]any, len(names))
+				for i, name := range names {
+					value := values[i]
+					if bytes, ok := value.([]byte); ok {
+						value = string(bytes)
+					}
+					raw[strings.ToLower(name)] = value
+				}
+				name, _ := raw[key].(string)
+				if column, ok := columns[name]; ok {
+					column.Raw = raw
+				}
+			}
+			return rows.Err()
+		})
+		if err != nil {
+			return fmt.Errorf("raw metadata of table %s: %w", table.Table, err)
+		}
+	}
+	return nil
+}
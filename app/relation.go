@@ -0,0 +1,166 @@
+package app
+
+import (
+	"slices"
+)
+
+// Relation A foreign key relationship between two tables.
+type Relation struct {
+	Table           *Table      // referencing table
+	ForeignKey      *ForeignKey // foreign key of the referencing table
+	ReferencedTable *Table      // referenced table, nil when the referenced table is not exported
+	SelfReferencing bool        // the table references itself, such as parent_id
+	PartOfCycle     bool        // both tables belong to the same foreign key cycle
+}
+
+// initRelations Build the relationship model and the topological order of all tables.
+func initRelations(tmp *Template) {
+	tmp.TablesTopological, tmp.TableCycles = sortTablesTopological(tmp.Tables)
+	exported := make(map[string]*Table, len(tmp.Tables))
+	for _, table := range tmp.Tables {
+		exported[table.Table] = table
+		table.Relations = nil
+		table.ReferencedBy = nil
+	}
+	cycle := make(map[string]int, len(tmp.Tables))
+	for i, names := range tmp.TableCycles {
+		for _, name := range names {
+			cycle[name] = i + 1
+		}
+	}
+	for _, table := range tmp.Tables {
+		for _, foreignKey := range table.ForeignKeys {
+			relation := &Relation{
+				Table:           table,
+				ForeignKey:      foreignKey,
+				ReferencedTable: exported[foreignKey.ReferencedTable],
+				SelfReferencing: foreignKey.ReferencedTable == table.Table,
+			}
+			if !relation.SelfReferencing && cycle[table.Table] > 0 {
+				relation.PartOfCycle = cycle[table.Table] == cycle[foreignKey.ReferencedTable]
+			}
+			table.Relations = append(table.Relations, relation)
+			if relation.ReferencedTable != nil {
+				relation.ReferencedTable.ReferencedBy = append(relation.ReferencedTable.ReferencedBy, relation)
+			}
+		}
+	}
+}
+
+// tableReferences The names of the exported tables referenced by the table, self-references are excluded.
+func tableReferences(table *Table, exported map[string]*Table) []string {
+	references := make([]string, 0, len(table.ForeignKeys))
+	for _, foreignKey := range table.ForeignKeys {
+		if foreignKey.ReferencedTable == table.Table {
+			continue
+		}
+		if _, ok := exported[foreignKey.ReferencedTable]; !ok {
+			continue
+		}
+		if slices.Contains(references, foreignKey.ReferencedTable) {
+			continue
+		}
+		references = append(references, foreignKey.ReferencedTable)
+	}
+	return references
+}
+
+// sortTablesTopological Sort tables so that referenced tables come before referencing tables.
+// The tables in foreign key cycles are appended in their original order, and each cycle is reported.
+func sortTablesTopological(tables []*Table) ([]*Table, [][]string) {
+	exported := make(map[string]*Table, len(tables))
+	for _, table := range tables {
+		exported[table.Table] = table
+	}
+	references := make(map[string][]string, len(tables))
+	referencedBy := make(map[string][]string, len(tables))
+	degree := make(map[string]int, len(tables))
+	for _, table := range tables {
+		references[table.Table] = tableReferences(table, exported)
+		degree[table.Table] = len(references[table.Table])
+		for _, referenced := range references[table.Table] {
+			referencedBy[referenced] = append(referencedBy[referenced], table.Table)
+		}
+	}
+
+	result := make([]*Table, 0, len(tables))
+	sorted := make(map[string]bool, len(tables))
+	for len(result) < len(tables) {
+		// Keep the original order among tables whose references are all satisfied
+		next := -1
+		for i, table := range tables {
+			if !sorted[table.Table] && degree[table.Table] == 0 {
+				next = i
+				break
+			}
+		}
+		if next < 0 {
+			break
+		}
+		table := tables[next]
+		sorted[table.Table] = true
+		result = append(result, table)
+		for _, referencing := range referencedBy[table.Table] {
+			degree[referencing]--
+		}
+	}
+
+	cycles := tableCycles(tables, references)
+	for _, table := range tables {
+		if !sorted[table.Table] {
+			result = append(result, table)
+		}
+	}
+	return result, cycles
+}
+
+// tableCycles Find the strongly connected components with more than one table, using Tarjan's algorithm.
+func tableCycles(tables []*Table, references map[string][]string) [][]string {
+	index := 0
+	indexes := make(map[string]int, len(tables))
+	lowLink := make(map[string]int, len(tables))
+	onStack := make(map[string]bool, len(tables))
+	stack := make([]string, 0, len(tables))
+	cycles := make([][]string, 0)
+
+	var connect func(name string)
+	connect = func(name string) {
+		indexes[name] = index
+		lowLink[name] = index
+		index++
+		stack = append(stack, name)
+		onStack[name] = true
+		for _, referenced := range references[name] {
+			if _, ok := indexes[referenced]; !ok {
+				connect(referenced)
+				lowLink[name] = min(lowLink[name], lowLink[referenced])
+			} else if onStack[referenced] {
+				lowLink[name] = min(lowLink[name], indexes[referenced])
+			}
+		}
+		if lowLink[name] != indexes[name] {
+			return
+		}
+		component := make([]string, 0)
+		for {
+			last := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[last] = false
+			component = append(component, last)
+			if last == name {
+				break
+			}
+		}
+		if len(component) > 1 {
+			slices.Sort(component)
+			cycles = append(cycles, component)
+		}
+	}
+
+	for _, table := range tables {
+		if _, ok := indexes[table.Table]; !ok {
+			connect(table.Table)
+		}
+	}
+	return cycles
+}
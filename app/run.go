@@ -0,0 +1,87 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// runCommands The commands of the run command, rendered from the table structure.
+var runCommands = []string{CmdCustom, CmdReplace, CmdSchema, CmdTable, CmdTest, CmdReset, CmdHey, CmdSnapshot, CmdLint, CmdDrift, CmdGraph, CmdCdc}
+
+// runOutput An output of the run command.
+type runOutput struct {
+	command string   // command rendering the output
+	output  string   // output file path, the standard output if empty
+	header  string   // text written before the output
+	options []Option // options of the configuration of the output, such as the custom template name
+}
+
+// runOutputs The outputs of the names, a name is a command or the name of a custom template of custom_templates.
+// The jobs of the command, or of the custom command with the name, write to their output files; the jobs with group enabled are not run.
+// The output of a name without a job is written to the standard output.
+func runOutputs(cfg *Config, names []string) ([]*runOutput, error) {
+	outputs := make([]*runOutput, 0, len(names))
+	for _, name := range names {
+		command, customName := name, ""
+		if !slices.Contains(runCommands, name) {
+			if _, ok := cfg.CustomTemplates[name]; !ok {
+				return nil, fmt.Errorf("run: %s is neither a command nor a custom template of custom_templates", name)
+			}
+			command, customName = CmdCustom, name
+		}
+		matched := false
+		for _, job := range cfg.Jobs {
+			if job.Group || job.Command != command || job.Name != customName {
+				continue
+			}
+			options := []Option{WithCustomName(customName)}
+			if strings.HasSuffix(job.Output, ".go") {
+				options = append(options, WithValidateGo())
+			}
+			outputs = append(outputs, &runOutput{command: command, output: job.Output, header: job.Header, options: options})
+			matched = true
+		}
+		if !matched {
+			outputs = append(outputs, &runOutput{command: command, options: []Option{WithCustomName(customName)}})
+		}
+	}
+	return outputs, nil
+}
+
+// RunCommands Run the commands in order over the connection of the App, the table structure is queried once and every output is rendered from it.
+// names are commands or the names of custom templates of custom_templates, such as schema table docs.
+func (s *App) RunCommands(ctx context.Context, names []string) error {
+	if len(names) == 0 {
+		return fmt.Errorf("run: no command is given")
+	}
+	outputs, err := runOutputs(s.cfg, names)
+	if err != nil {
+		return err
+	}
+	tmp, err := s.queryTemplate(ctx)
+	if err != nil {
+		return err
+	}
+	for _, v := range outputs {
+		// The configuration of the output is a copy, the options do not change the other outputs
+		cfg := *s.cfg
+		for _, option := range v.options {
+			option(&cfg)
+		}
+		app := &App{cfg: &cfg, way: s.way, schema: s.schema, mutex: s.mutex}
+		content, err := app.NewOutput(v.command)(ctx, tmp)
+		if err != nil {
+			return fmt.Errorf("run %s: %w", v.command, err)
+		}
+		if v.header != "" {
+			// The header is formatted with the output, such as the crlf line endings
+			content = formatOutput(&cfg, append([]byte(v.header+"\n"), content...))
+		}
+		if err = writeJobOutput(v.output, content); err != nil {
+			return fmt.Errorf("run %s: %w", v.command, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package app
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/cd365/hey/v7"
+	"github.com/cd365/hey/v7/cst"
+)
+
+// sampleValueMaxLength Sampled values longer than this are truncated.
+const sampleValueMaxLength = 64
+
+// sampleTables Read at most sample_rows rows of each table to populate the example values of the columns.
+// The secret and pii columns are never read.
+func sampleTables(ctx context.Context, cfg *Config, way *hey.Way, tables []*Table) error {
+	if cfg.SampleRows <= 0 {
+		return nil
+	}
+	dialect := string(way.Config().Manual.DatabaseType)
+	for _, table := range tables {
+		columns := make([]*Column, 0, len(table.Columns))
+		for _, column := range table.Columns {
+			if column.Sensitivity == SensitivitySecret || column.Sensitivity == SensitivityPii {
+				continue
+			}
+			columns = append(columns, column)
+		}
+		if len(columns) == 0 {
+			continue
+		}
+		names := make([]string, 0, len(columns))
+		for _, column := range columns {
+			names = append(names, quoteIdentifier(dialect, column.Column))
+		}
+		name := quoteIdentifier(dialect, table.Table)
+		if table.Database != "" && dialect != string(cst.Sqlite) {
+			name = quoteIdentifier(dialect, table.Database) + "." + name
+		}
+		prepare := sampleQuery(dialect, strings.Join(names, ", "), name, cfg.SampleRows)
+		err := way.Query(ctx, hey.NewSQL(prepare), func(rows *sql.Rows) error {
+			values := make([]sql.NullString, len(columns))
+			dest := make([]any, len(columns))
+			for i := range values {
+				dest[i] = &values[i]
+			}
+			for rows.Next() {
+				if err := rows.Scan(dest...); err != nil {
+					return err
+				}
+				for i, column := range columns {
+					if !values[i].Valid {
+						continue
+					}
+					value := sampleValue(values[i].String)
+					if !slices.Contains(column.ExampleValues, value) {
+						column.ExampleValues = append(column.ExampleValues, value)
+					}
+				}
+			}
+			return rows.Err()
+		})
+		if err != nil {
+			return fmt.Errorf("sample table %s: %w", table.Table, err)
+		}
+	}
+	return nil
+}
+
+// sampleQuery Select at most rows rows with the row-limiting clause of the dialect.
+// Oracle, DB2 => FETCH FIRST n ROWS ONLY | Firebird => SELECT FIRST n | others => LIMIT n
+func sampleQuery(dialect string, columns string, table string, rows int) string {
+	switch dialect {
+	case DriverOracle, DriverDb2:
+		return fmt.Sprintf("SELECT %s FROM %s FETCH FIRST %d ROWS ONLY", columns, table, rows)
+	case DriverFirebird:
+		return fmt.Sprintf("SELECT FIRST %d %s FROM %s", rows, columns, table)
+	}
+	return fmt.Sprintf("SELECT %s FROM %s LIMIT %d", columns, table, rows)
+}
+
+// sampleValue Single line value, truncated to sampleValueMaxLength characters.
+func sampleValue(value string) string {
+	value = removeNewlineCharacter(value)
+	if runes := []rune(value); len(runes) > sampleValueMaxLength {
+		value = string(runes[:sampleValueMaxLength]) + "..."
+	}
+	return value
+}
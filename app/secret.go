@@ -0,0 +1,108 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+const (
+	SecretEnv  = "env:"
+	SecretFile = "file:"
+	SecretExec = "exec:"
+)
+
+// resolveSecret Resolve a secret reference: env:NAME, file:/path/to/secret, exec:command args...; other values are returned as is.
+// file: references are not resolved when files is false, such as SQLite file: URIs.
+func resolveSecret(ctx context.Context, value string, files bool) (string, error) {
+	switch {
+	case strings.HasPrefix(value, SecretEnv):
+		name := strings.TrimPrefix(value, SecretEnv)
+		secret, ok := os.LookupEnv(name)
+		if !ok {
+			return "", fmt.Errorf("secret environment variable %s is not set", name)
+		}
+		return secret, nil
+	case files && strings.HasPrefix(value, SecretFile):
+		content, err := os.ReadFile(strings.TrimPrefix(value, SecretFile))
+		if err != nil {
+			return "", fmt.Errorf("secret file: %w", err)
+		}
+		return strings.TrimSpace(string(content)), nil
+	case strings.HasPrefix(value, SecretExec):
+		command, err := secretCommand(strings.TrimPrefix(value, SecretExec))
+		if err != nil {
+			return "", err
+		}
+		if len(command) == 0 {
+			return "", fmt.Errorf("secret command is empty")
+		}
+		output, err := exec.CommandContext(ctx, command[0], command[1:]...).Output()
+		if err != nil {
+			return "", fmt.Errorf("secret command %s: %w", command[0], err)
+		}
+		return strings.TrimSpace(string(output)), nil
+	}
+	return value, nil
+}
+
+// secretCommand Split the exec: command into the arguments like a shell without expansions: an argument containing spaces is quoted
+// with single or double quotes, such as exec:op read "op://vault/My Item/password"; a backslash escapes the next character outside single quotes.
+func secretCommand(command string) ([]string, error) {
+	args := make([]string, 0)
+	arg := &strings.Builder{}
+	quoted, quote, escape := false, rune(0), false
+	for _, c := range command {
+		switch {
+		case escape:
+			arg.WriteRune(c)
+			escape = false
+		case c == '\\' && quote != '\'':
+			escape = true
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			} else {
+				arg.WriteRune(c)
+			}
+		case c == '\'' || c == '"':
+			quote, quoted = c, true
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			if quoted || arg.Len() > 0 {
+				args = append(args, arg.String())
+			}
+			arg.Reset()
+			quoted = false
+		default:
+			arg.WriteRune(c)
+		}
+	}
+	if quote != 0 || escape {
+		return nil, fmt.Errorf("secret command: unterminated quote or escape")
+	}
+	if quoted || arg.Len() > 0 {
+		args = append(args, arg.String())
+	}
+	return args, nil
+}
+
+// applySecrets Resolve the secret references of the password, data_source_name, url and replica.
+func applySecrets(ctx context.Context, cfg *Config) (err error) {
+	db := &cfg.Database
+	sqlite := db.Driver == "sqlite" || db.Driver == "sqlite3"
+	if db.Password, err = resolveSecret(ctx, db.Password, true); err != nil {
+		return
+	}
+	if db.DataSourceName, err = resolveSecret(ctx, db.DataSourceName, !sqlite); err != nil {
+		return
+	}
+	if db.Url, err = resolveSecret(ctx, db.Url, false); err != nil {
+		return
+	}
+	if db.Replica, err = resolveSecret(ctx, db.Replica, false); err != nil {
+		return
+	}
+	return
+}
@@ -0,0 +1,351 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/cd365/hey/v7/cst"
+)
+
+// seedMaxAttempts The attempts of a row before the seed gives up on the unique constraints.
+const seedMaxAttempts = 100
+
+// seedCheckIn The allowed values of the column in the table DDL, %s is the column name:
+// CHECK (status IN ('a', 'b')) of MySQL and SQLite, CHECK ((status = ANY (ARRAY['a'::text, 'b'::text]))) of PostgreSQL.
+const seedCheckIn = `(?i)(?:^|[^\w$])[\x60"]?%s[\x60"]?\)*(?:::[\w ]+\)*)?\s+(?:IN\s*\(|=\s*ANY\s*\(+ARRAY\[)([^\])]*)`
+
+var (
+	seedString = regexp.MustCompile(`'((?:[^']|'')*)'`)
+	seedNumber = regexp.MustCompile(`^-?\d+(?:\.\d+)?$`)
+)
+
+// seedTable The generated rows of a table, the values are SQL literals by the column name.
+type seedTable struct {
+	table *Table
+	rows  []map[string]string
+}
+
+// seedAllowedValues The values of the MySQL enum or the CHECK IN list of the column as SQL literals, nil if the values are not restricted.
+func seedAllowedValues(table *Table, column *Column) []string {
+	list := ""
+	if column.dataType() == "enum" && column.Type != nil {
+		list = *column.Type
+	} else if table.Defined != "" {
+		check := regexp.MustCompile(fmt.Sprintf(seedCheckIn, regexp.QuoteMeta(column.Column)))
+		if match := check.FindStringSubmatch(table.Defined); match != nil {
+			list = match[1]
+		}
+	}
+	if list == "" {
+		return nil
+	}
+	values := make([]string, 0)
+	// The character set introducers of MySQL are ignored, _utf8mb4'a' => 'a'
+	for _, match := range seedString.FindAllStringSubmatch(list, -1) {
+		values = append(values, "'"+match[1]+"'")
+	}
+	if len(values) > 0 {
+		return values
+	}
+	for _, value := range strings.Split(list, ",") {
+		if value = strings.TrimSpace(value); seedNumber.MatchString(value) {
+			values = append(values, value)
+		}
+	}
+	if len(values) == 0 {
+		return nil
+	}
+	return values
+}
+
+// seedGenerated Whether the value of the column is generated by the database, such as the STORED GENERATED columns.
+func seedGenerated(column *Column) bool {
+	return column.Extra != nil && strings.HasSuffix(strings.ToUpper(*column.Extra), " GENERATED")
+}
+
+// seedText A random lower case alphanumeric text of the length.
+func seedText(random *rand.Rand, length int) string {
+	const letters = "abcdefghijklmnopqrstuvwxyz0123456789"
+	text := make([]byte, length)
+	for i := range text {
+		text[i] = letters[random.IntN(len(letters))]
+	}
+	return string(text)
+}
+
+// seedValue A random SQL literal of the column type, row is the zero based row number of the table.
+func seedValue(dialect string, random *rand.Rand, column *Column, row int) string {
+	precision := func(value int) int {
+		if column.NumericPrecision != nil && *column.NumericPrecision > 0 {
+			return min(*column.NumericPrecision, value)
+		}
+		return value
+	}
+	integer := func(maximum int64) string {
+		// The values fit in the digits of the numeric precision, such as 999 of NUMERIC(3)
+		limit := int64(1)
+		for range precision(18) {
+			limit *= 10
+		}
+		return fmt.Sprintf("%d", 1+random.Int64N(min(maximum, limit-1)))
+	}
+	date := func() string {
+		return fmt.Sprintf("%04d-%02d-%02d", 2020+random.IntN(6), 1+random.IntN(12), 1+random.IntN(28))
+	}
+	clock := func() string {
+		return fmt.Sprintf("%02d:%02d:%02d", random.IntN(24), random.IntN(60), random.IntN(60))
+	}
+	// The SQLite data type is the declared type, such as decimal(10,2)
+	datatype := column.dataType()
+	if i := strings.IndexByte(datatype, '('); i > 0 {
+		datatype = strings.TrimSpace(datatype[:i])
+	}
+	switch datatype {
+	case "bool", "boolean":
+		if dialect == string(cst.Postgresql) {
+			return []string{"FALSE", "TRUE"}[random.IntN(2)]
+		}
+		return fmt.Sprintf("%d", random.IntN(2))
+	case "tinyint", "utinyint":
+		return integer(127)
+	case "smallint", "smallserial", "int2", "usmallint":
+		return integer(32767)
+	case "integer", "int", "serial", "mediumint", "int4", "bigint", "bigserial", "int8", "uinteger", "ubigint", "hugeint":
+		return integer(1000000)
+	case "decimal", "numeric", "number", "real", "float", "double", "double precision", "money", "smalldecimal", "decfloat":
+		scale := 2
+		if column.NumericScale != nil && *column.NumericScale >= 0 {
+			scale = *column.NumericScale
+		}
+		digits := max(precision(scale+6)-scale, 0)
+		limit := int64(1)
+		for range min(digits, 6) {
+			limit *= 10
+		}
+		value := fmt.Sprintf("%d", random.Int64N(limit))
+		if scale > 0 {
+			value += "." + fmt.Sprintf("%0*d", scale, random.Int64N(100))[:scale]
+		}
+		return value
+	case "date":
+		return "'" + date() + "'"
+	case "time", "time without time zone", "timetz", "time with time zone":
+		return "'" + clock() + "'"
+	case "timestamp", "timestamp without time zone", "datetime", "timestamptz", "timestamp with time zone", "seconddate":
+		return "'" + date() + " " + clock() + "'"
+	case "year":
+		return fmt.Sprintf("%d", 2000+random.IntN(30))
+	case "uuid":
+		value := fmt.Sprintf("%016x%016x", random.Uint64(), random.Uint64())
+		return fmt.Sprintf("'%s-%s-4%s-a%s-%s'", value[0:8], value[8:12], value[13:16], value[17:20], value[20:32])
+	case "json", "jsonb":
+		return fmt.Sprintf(`'{"seed": %d}'`, row+1)
+	case "binary", "varbinary", "tinyblob", "blob", "mediumblob", "longblob", "bytea", "bytes":
+		value := fmt.Sprintf("%016x", random.Uint64())
+		if dialect == string(cst.Postgresql) {
+			return `'\x` + value + `'`
+		}
+		return "X'" + value + "'"
+	case "inet", "cidr":
+		return fmt.Sprintf("'10.%d.%d.%d'", random.IntN(256), random.IntN(256), 1+random.IntN(254))
+	}
+	// The text is prefixed with the column name if the length allows it, such as email_k3x9a2
+	length := 0
+	if column.CharacterMaximumLength != nil && *column.CharacterMaximumLength > 0 {
+		length = *column.CharacterMaximumLength
+	}
+	if length == 0 || length >= len(column.Column)+7 {
+		return fmt.Sprintf("'%s_%s'", strings.ReplaceAll(column.Column, "'", "''"), seedText(random, 6))
+	}
+	return "'" + seedText(random, min(length, 8)) + "'"
+}
+
+// seedReferenced The rows of the referenced table of the foreign key and the referenced columns, the primary key if the columns are not reported.
+func seedReferenced(foreignKey *ForeignKey, referenced *seedTable) []string {
+	if !slices.Contains(foreignKey.ReferencedColumns, "") {
+		return foreignKey.ReferencedColumns
+	}
+	for _, index := range referenced.table.Indexes {
+		if index.Primary {
+			return index.Columns
+		}
+	}
+	return foreignKey.ReferencedColumns
+}
+
+// seedRows Generate the rows of the table: the integer primary key is the row number, the foreign keys reuse the rows of the referenced tables
+// and the values of the unique indexes are not repeated; a nullable foreign key referencing a table of a foreign key cycle is null.
+func seedRows(dialect string, random *rand.Rand, table *Table, count int, generated map[string]*seedTable) (*seedTable, error) {
+	result := &seedTable{table: table, rows: make([]map[string]string, 0, count)}
+	columns := make(map[string]*Column, len(table.Columns))
+	allowed := make(map[string][]string, len(table.Columns))
+	for _, column := range table.Columns {
+		columns[column.Column] = column
+		allowed[column.Column] = seedAllowedValues(table, column)
+	}
+	sequence := ""
+	uniques := make([]*Index, 0)
+	for _, index := range table.Indexes {
+		if index.Primary && len(index.Columns) == 1 && columns[index.Columns[0]] != nil && strings.HasPrefix(strings.TrimPrefix(columns[index.Columns[0]].goType(), "*"), "int") {
+			sequence = index.Columns[0]
+		}
+		if index.Unique || index.Primary {
+			uniques = append(uniques, index)
+		}
+	}
+	if sequence == "" && table.AutoIncrementColumn != "" {
+		sequence = table.AutoIncrementColumn
+	}
+	// The foreign keys covered by a unique index pick the referenced rows in turn, such as the one to one relations
+	unique := func(foreignKey *ForeignKey) bool {
+		for _, index := range uniques {
+			if len(index.Columns) <= len(foreignKey.Columns) && !slices.ContainsFunc(index.Columns, func(c string) bool { return !slices.Contains(foreignKey.Columns, c) }) {
+				return true
+			}
+		}
+		return false
+	}
+	seen := make([]map[string]*struct{}, len(uniques))
+	for i := range seen {
+		seen[i] = make(map[string]*struct{}, count)
+	}
+	for row := range count {
+		var values map[string]string
+		var keys []string
+		for attempt := 0; ; attempt++ {
+			if attempt == seedMaxAttempts {
+				return nil, fmt.Errorf("table %s: no unique values of the row %d after %d attempts", table.Table, row+1, seedMaxAttempts)
+			}
+			values = make(map[string]string, len(table.Columns))
+			for _, column := range table.Columns {
+				switch {
+				case column.Column == sequence:
+					values[column.Column] = fmt.Sprintf("%d", row+1)
+				case len(allowed[column.Column]) > 0:
+					values[column.Column] = allowed[column.Column][random.IntN(len(allowed[column.Column]))]
+				default:
+					values[column.Column] = seedValue(dialect, random, column, row)
+				}
+			}
+			for _, foreignKey := range table.ForeignKeys {
+				referenced := generated[foreignKey.ReferencedTable]
+				if foreignKey.ReferencedTable == table.Table {
+					// The first row of a self reference references itself
+					referenced = &seedTable{table: table, rows: append(slices.Clip(result.rows), values)}
+				}
+				nullable := !slices.ContainsFunc(foreignKey.Columns, func(c string) bool { return columns[c] != nil && !columns[c].nullable() })
+				if referenced == nil || len(referenced.rows) == 0 {
+					if !nullable {
+						return nil, fmt.Errorf("table %s: the referenced table %s of the foreign key %s has no seed rows, it is not exported or it is in a foreign key cycle", table.Table, foreignKey.ReferencedTable, foreignKey.Name)
+					}
+					for _, column := range foreignKey.Columns {
+						values[column] = "NULL"
+					}
+					continue
+				}
+				pick := random.IntN(len(referenced.rows))
+				if unique(foreignKey) {
+					pick = (row + attempt) % len(referenced.rows)
+				}
+				referencedColumns := seedReferenced(foreignKey, referenced)
+				for i, column := range foreignKey.Columns {
+					if i < len(referencedColumns) {
+						values[column] = referenced.rows[pick][referencedColumns[i]]
+					}
+				}
+			}
+			keys = make([]string, len(uniques))
+			duplicate := false
+			for i, index := range uniques {
+				parts := make([]string, 0, len(index.Columns))
+				for _, column := range index.Columns {
+					parts = append(parts, values[column])
+				}
+				keys[i] = strings.Join(parts, "\x00")
+				if _, ok := seen[i][keys[i]]; ok && !slices.Contains(parts, "NULL") {
+					duplicate = true
+					break
+				}
+			}
+			if !duplicate {
+				break
+			}
+		}
+		for i, key := range keys {
+			seen[i][key] = nil
+		}
+		result.rows = append(result.rows, values)
+	}
+	return result, nil
+}
+
+// seed The INSERT statements of the random rows of the exported tables, referenced tables come before referencing tables.
+// The generated columns are omitted, the PostgreSQL sequences of the auto-increment columns are set to the last row.
+func seed(tmp *Template, count int, random *rand.Rand) ([]byte, error) {
+	dialect := migrationDialect(tmp.Dialect)
+	switch dialect {
+	case string(cst.Postgresql), string(cst.Mysql), string(cst.Sqlite):
+	default:
+		return nil, fmt.Errorf("seed: unsupported dialect %s, supported dialects: postgresql, mysql, sqlite", tmp.Dialect)
+	}
+	generated := make(map[string]*seedTable, len(tmp.TablesTopological))
+	buf := &strings.Builder{}
+	for _, table := range tmp.TablesTopological {
+		result, err := seedRows(dialect, random, table, count, generated)
+		if err != nil {
+			return nil, fmt.Errorf("seed: %w", err)
+		}
+		generated[table.Table] = result
+		columns := make([]*Column, 0, len(table.Columns))
+		names := make([]string, 0, len(table.Columns))
+		for _, column := range table.Columns {
+			if seedGenerated(column) {
+				continue
+			}
+			columns = append(columns, column)
+			names = append(names, quoteIdentifier(dialect, column.Column))
+		}
+		if len(columns) == 0 || len(result.rows) == 0 {
+			continue
+		}
+		rows := make([]string, 0, len(result.rows))
+		for _, values := range result.rows {
+			row := make([]string, 0, len(columns))
+			for _, column := range columns {
+				row = append(row, values[column.Column])
+			}
+			rows = append(rows, "("+strings.Join(row, ", ")+")")
+		}
+		overriding := ""
+		if dialect == string(cst.Postgresql) && table.AutoIncrementColumn != "" {
+			// The values of the GENERATED ALWAYS AS IDENTITY columns are rejected without OVERRIDING SYSTEM VALUE
+			overriding = " OVERRIDING SYSTEM VALUE"
+		}
+		name := quoteIdentifier(dialect, table.Table)
+		buf.WriteString(fmt.Sprintf("INSERT INTO %s (%s)%s VALUES\n\t%s;\n", name, strings.Join(names, ", "), overriding, strings.Join(rows, ",\n\t")))
+		if overriding != "" {
+			column := strings.ReplaceAll(table.AutoIncrementColumn, "'", "''")
+			buf.WriteString(fmt.Sprintf("SELECT setval(pg_get_serial_sequence('%s', '%s'), (SELECT MAX(%s) FROM %s));\n", strings.ReplaceAll(name, "'", "''"), column, quoteIdentifier(dialect, table.AutoIncrementColumn), name))
+		}
+	}
+	return []byte(buf.String()), nil
+}
+
+// Seed The INSERT statements of the random rows of the exported tables, count rows per table; the same seed produces the same rows.
+// The foreign keys, the unique indexes, the MySQL enums and the CHECK IN lists of the table DDL are honored.
+func (s *App) Seed(ctx context.Context, count int, source uint64) ([]byte, error) {
+	if count <= 0 {
+		return nil, fmt.Errorf("seed: the number of rows must be greater than 0")
+	}
+	return s.Run(ctx, func(ctx context.Context, tmp *Template) ([]byte, error) {
+		content, err := seed(tmp, count, rand.New(rand.NewPCG(source, source)))
+		if err != nil {
+			return nil, err
+		}
+		return formatOutput(s.cfg, content), nil
+	})
+}
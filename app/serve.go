@@ -0,0 +1,180 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// grpcServe Serve the schema service over gRPC, set by the grpc build tag; nil if pts is built without gRPC support.
+var grpcServe func(ctx context.Context, address string, service *SchemaService) error
+
+// ServeGrpc Serve the schema service over gRPC until the context is done, the messages are the JSON of the request and response types.
+func ServeGrpc(ctx context.Context, address string, service *SchemaService) error {
+	if grpcServe == nil {
+		return fmt.Errorf("serve: pts is built without gRPC support, build it with -tags grpc")
+	}
+	return grpcServe(ctx, address, service)
+}
+
+// SchemaService Schema operations of the serve command, the requests share the database connection of the App.
+type SchemaService struct {
+	app *App
+	// The requests are executed one at a time, Run creates and drops the PostgreSQL helper functions
+	mutex sync.Mutex
+}
+
+// NewSchemaService Create the schema service of the App.
+func NewSchemaService(app *App) *SchemaService {
+	return &SchemaService{app: app}
+}
+
+type ListTablesRequest struct{}
+
+type ListTablesResponse struct {
+	Tables []string `json:"tables"` // exported table names
+}
+
+type DescribeTableRequest struct {
+	Table string `json:"table"` // table name
+}
+
+type DescribeTableResponse struct {
+	Table *Table `json:"table"` // table structure, the same as the tables of the snapshot command
+}
+
+type RenderRequest struct {
+	Command string   `json:"command"`          // custom, replace, schema, table, test, reset, hey, snapshot, lint, graph, cdc
+	Tables  []string `json:"tables,omitempty"` // only export the tables, all tables if empty
+}
+
+type RenderResponse struct {
+	Content string `json:"content"` // output of the command
+}
+
+type DiffRequest struct {
+	Snapshot string   `json:"snapshot"`         // baseline snapshot, the output of the snapshot command
+	Tables   []string `json:"tables,omitempty"` // only compare the tables, all tables if empty
+}
+
+type DiffResponse struct {
+	Drift *Drift `json:"drift,omitempty"` // tables changed since the baseline snapshot, nil if nothing changed
+}
+
+// only The App exporting only the tables, the configuration of the service App is not modified.
+// The tables are limited to the only_table tables of the service and the disable_table tables are not exported, all exported tables if empty.
+func (s *SchemaService) only(tables []string) (*App, error) {
+	cfg := *s.app.cfg
+	if len(tables) > 0 {
+		configured := make(map[string]*struct{}, len(cfg.OnlyTable))
+		for _, table := range cfg.OnlyTable {
+			configured[tableKey(&cfg, table)] = nil
+		}
+		exported := make([]string, 0, len(tables))
+		for _, table := range tables {
+			if _, ok := configured[tableKey(&cfg, table)]; (ok || len(configured) == 0) && !isTableDisabled(&cfg, table) {
+				exported = append(exported, table)
+			}
+		}
+		if len(exported) == 0 {
+			return nil, fmt.Errorf("tables are not exported: %s", strings.Join(tables, ", "))
+		}
+		cfg.OnlyTable = exported
+	}
+	return &App{
+		cfg:    &cfg,
+		way:    s.app.way,
+		schema: s.app.schema,
+		mutex:  s.app.mutex,
+	}, nil
+}
+
+// run Run the output function with the App.
+func (s *SchemaService) run(ctx context.Context, app *App, output func(ctx context.Context, tmp *Template) ([]byte, error)) ([]byte, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return app.Run(ctx, output)
+}
+
+// ListTables The exported table names.
+func (s *SchemaService) ListTables(ctx context.Context, request *ListTablesRequest) (*ListTablesResponse, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	tables, err := s.app.TableNames(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &ListTablesResponse{Tables: tables}, nil
+}
+
+// DescribeTable The structure of the table.
+func (s *SchemaService) DescribeTable(ctx context.Context, request *DescribeTableRequest) (*DescribeTableResponse, error) {
+	if request.Table == "" {
+		return nil, fmt.Errorf("describe table: the table is not set")
+	}
+	app, err := s.only([]string{request.Table})
+	if err != nil {
+		return nil, fmt.Errorf("describe table: %w", err)
+	}
+	response := &DescribeTableResponse{}
+	_, err = s.run(ctx, app, func(ctx context.Context, tmp *Template) ([]byte, error) {
+		for _, table := range tmp.Tables {
+			if tableKey(s.app.cfg, table.Table) == tableKey(s.app.cfg, request.Table) {
+				response.Table = table
+			}
+		}
+		return nil, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if response.Table == nil {
+		return nil, fmt.Errorf("describe table: table %s does not exist", request.Table)
+	}
+	return response, nil
+}
+
+// Render The output of the command.
+func (s *SchemaService) Render(ctx context.Context, request *RenderRequest) (*RenderResponse, error) {
+	switch request.Command {
+	case CmdCustom, CmdReplace, CmdSchema, CmdTable, CmdTest, CmdReset, CmdHey, CmdSnapshot, CmdLint, CmdGraph, CmdCdc:
+	default:
+		return nil, fmt.Errorf("render: invalid command: %s", request.Command)
+	}
+	app, err := s.only(request.Tables)
+	if err != nil {
+		return nil, fmt.Errorf("render: %w", err)
+	}
+	content, err := s.run(ctx, app, app.NewOutput(request.Command))
+	if err != nil {
+		return nil, err
+	}
+	return &RenderResponse{Content: string(content)}, nil
+}
+
+// Diff The tables changed since the baseline snapshot, the changes are posted to the webhook of the drift configuration.
+func (s *SchemaService) Diff(ctx context.Context, request *DiffRequest) (*DiffResponse, error) {
+	previous, err := ParseSnapshot([]byte(request.Snapshot))
+	if err != nil {
+		return nil, err
+	}
+	app, err := s.only(request.Tables)
+	if err != nil {
+		return nil, fmt.Errorf("diff: %w", err)
+	}
+	response := &DiffResponse{}
+	_, err = s.run(ctx, app, func(ctx context.Context, tmp *Template) ([]byte, error) {
+		response.Drift = schemaDrift(filterTables(app.cfg, previous.Tables), tmp.Tables)
+		return nil, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if response.Drift != nil && app.cfg.Drift.Webhook != "" {
+		if err = postWebhook(ctx, app.cfg, response.Drift); err != nil {
+			return nil, err
+		}
+	}
+	return response, nil
+}
@@ -0,0 +1,89 @@
+//go:build grpc
+
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding"
+)
+
+func init() {
+	encoding.RegisterCodec(jsonCodec{})
+	grpcServe = serveGrpc
+}
+
+// jsonCodec The messages are the JSON of the request and response types of the schema service, the content type is application/grpc+json.
+// There are no protobuf messages, the clients register a JSON codec named json and call with grpc.CallContentSubtype("json").
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(v any) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (jsonCodec) Unmarshal(data []byte, v any) error {
+	return json.Unmarshal(data, v)
+}
+
+func (jsonCodec) Name() string {
+	return "json"
+}
+
+// schemaServer The methods of pts.v1.SchemaService.
+type schemaServer interface {
+	ListTables(ctx context.Context, request *ListTablesRequest) (*ListTablesResponse, error)
+	DescribeTable(ctx context.Context, request *DescribeTableRequest) (*DescribeTableResponse, error)
+	Render(ctx context.Context, request *RenderRequest) (*RenderResponse, error)
+	Diff(ctx context.Context, request *DiffRequest) (*DiffResponse, error)
+}
+
+// unaryMethod The gRPC method calling the method of the schema service.
+func unaryMethod[Request any, Response any](name string, method func(*SchemaService, context.Context, *Request) (*Response, error)) grpc.MethodDesc {
+	return grpc.MethodDesc{
+		MethodName: name,
+		Handler: func(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+			request := new(Request)
+			if err := dec(request); err != nil {
+				return nil, err
+			}
+			handler := func(ctx context.Context, request any) (any, error) {
+				return method(srv.(*SchemaService), ctx, request.(*Request))
+			}
+			if interceptor == nil {
+				return handler(ctx, request)
+			}
+			info := &grpc.UnaryServerInfo{
+				Server:     srv,
+				FullMethod: "/pts.v1.SchemaService/" + name,
+			}
+			return interceptor(ctx, request, info, handler)
+		},
+	}
+}
+
+var schemaServiceDesc = grpc.ServiceDesc{
+	ServiceName: "pts.v1.SchemaService",
+	HandlerType: (*schemaServer)(nil),
+	Methods: []grpc.MethodDesc{
+		unaryMethod("ListTables", (*SchemaService).ListTables),
+		unaryMethod("DescribeTable", (*SchemaService).DescribeTable),
+		unaryMethod("Render", (*SchemaService).Render),
+		unaryMethod("Diff", (*SchemaService).Diff),
+	},
+}
+
+// serveGrpc Serve the schema service until the context is done, the running requests are completed before returning.
+func serveGrpc(ctx context.Context, address string, service *SchemaService) error {
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return err
+	}
+	server := grpc.NewServer()
+	server.RegisterService(&schemaServiceDesc, service)
+	stop := context.AfterFunc(ctx, server.GracefulStop)
+	defer stop()
+	return server.Serve(listener)
+}
@@ -0,0 +1,101 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cd365/hey/v7/cst"
+)
+
+// sqlType The column type as DDL of the dialect: postgresql, mysql, sqlite; the length, precision and scale of the column are kept.
+// The database types without an equivalent type are mapped to the closest type, such as uuid => CHAR(36) in MySQL.
+func sqlType(dialect string, column *Column) (string, error) {
+	var postgresql bool
+	switch strings.ToLower(dialect) {
+	case string(cst.Sqlite), "sqlite3":
+		return sqliteType(column), nil
+	case string(cst.Postgresql), "postgres", DriverPgx:
+		postgresql = true
+	case string(cst.Mysql):
+	default:
+		return "", fmt.Errorf("sqlType: unsupported dialect: %s", dialect)
+	}
+	pick := func(postgresqlType string, mysqlType string) string {
+		if postgresql {
+			return postgresqlType
+		}
+		return mysqlType
+	}
+	length := func(name string) string {
+		if column.CharacterMaximumLength == nil || *column.CharacterMaximumLength <= 0 {
+			return name
+		}
+		return fmt.Sprintf("%s(%d)", name, *column.CharacterMaximumLength)
+	}
+	// The fractional seconds precision of the time and timestamp types, the PostgreSQL default precision 6 is omitted
+	fraction := func(name string, suffix string) string {
+		if column.DatetimePrecision == nil || *column.DatetimePrecision <= 0 || (postgresql && *column.DatetimePrecision == 6) {
+			return name + suffix
+		}
+		return fmt.Sprintf("%s(%d)%s", name, *column.DatetimePrecision, suffix)
+	}
+	datatype := column.dataType()
+	switch datatype {
+	case "tinyint":
+		return pick("SMALLINT", "TINYINT"), nil
+	case "smallint", "smallserial":
+		return "SMALLINT", nil
+	case "integer", "int", "serial", "mediumint":
+		return pick("INTEGER", "INT"), nil
+	case "bigint", "bigserial":
+		return "BIGINT", nil
+	case "decimal", "numeric", "number", "smalldecimal":
+		name := pick("NUMERIC", "DECIMAL")
+		if column.NumericPrecision == nil || *column.NumericPrecision <= 0 {
+			return name, nil
+		}
+		if column.NumericScale == nil {
+			return fmt.Sprintf("%s(%d)", name, *column.NumericPrecision), nil
+		}
+		return fmt.Sprintf("%s(%d,%d)", name, *column.NumericPrecision, *column.NumericScale), nil
+	case "real", "float":
+		return pick("REAL", "FLOAT"), nil
+	case "double precision", "double":
+		return pick("DOUBLE PRECISION", "DOUBLE"), nil
+	case "bool", "boolean":
+		return pick("BOOLEAN", "TINYINT(1)"), nil
+	case "char", "character", "nchar":
+		return length("CHAR"), nil
+	case "character varying", "varchar", "nvarchar":
+		// MySQL VARCHAR requires the length
+		if !postgresql && (column.CharacterMaximumLength == nil || *column.CharacterMaximumLength <= 0) {
+			return "TEXT", nil
+		}
+		return length("VARCHAR"), nil
+	case "tinytext", "mediumtext", "longtext":
+		return pick("TEXT", strings.ToUpper(datatype)), nil
+	case "binary", "varbinary":
+		return pick("BYTEA", length(strings.ToUpper(datatype))), nil
+	case "tinyblob", "blob", "mediumblob", "longblob":
+		return pick("BYTEA", strings.ToUpper(datatype)), nil
+	case "bytea":
+		return pick("BYTEA", "LONGBLOB"), nil
+	case "date":
+		return "DATE", nil
+	case "time", "time without time zone":
+		return fraction("TIME", ""), nil
+	case "timetz", "time with time zone":
+		return pick(fraction("TIME", " WITH TIME ZONE"), fraction("TIME", "")), nil
+	case "timestamp", "timestamp without time zone", "datetime", "timestamp_ntz", "seconddate":
+		return pick(fraction("TIMESTAMP", ""), fraction("DATETIME", "")), nil
+	case "timestamptz", "timestamp with time zone", "timestamp_tz", "timestamp_ltz":
+		return pick(fraction("TIMESTAMP", " WITH TIME ZONE"), fraction("TIMESTAMP", "")), nil
+	case "json":
+		return "JSON", nil
+	case "jsonb", "variant", "object", "array":
+		return pick("JSONB", "JSON"), nil
+	case "uuid":
+		return pick("UUID", "CHAR(36)"), nil
+	}
+	return "TEXT", nil
+}
@@ -24,6 +24,15 @@ var (
 
 	//go:embed template/default_replace
 	defaultReplaceTemplate []byte
+
+	//go:embed template/default_test
+	defaultTestTemplate []byte
+
+	//go:embed template/default_reset
+	defaultResetTemplate []byte
+
+	//go:embed template/default_hey
+	defaultHeyTemplate []byte
 )
 
 //go:embed example.yaml
@@ -0,0 +1,26 @@
+package app
+
+import (
+	"strings"
+
+	"github.com/cd365/hey/v7/cst"
+)
+
+// columnWithTimeZone Whether the temporal column has a time zone: the value is an absolute point in time or keeps its offset.
+// PostgreSQL timestamptz and timetz, Oracle WITH [LOCAL] TIME ZONE, Snowflake TIMESTAMP_TZ and TIMESTAMP_LTZ;
+// MySQL TIMESTAMP is converted from the session time zone to UTC, BigQuery and Spanner TIMESTAMP and ClickHouse DateTime are absolute points in time.
+func columnWithTimeZone(dialect string, column *Column) bool {
+	datatype := column.dataType()
+	if strings.Contains(datatype, "with time zone") || strings.Contains(datatype, "with local time zone") {
+		return true
+	}
+	switch datatype {
+	case "timestamptz", "timetz", "timestamp_tz", "timestamp_ltz":
+		return true
+	case "timestamp":
+		return dialect == string(cst.Mysql) || dialect == DriverBigquery || dialect == DriverSpanner
+	case "datetime", "datetime64":
+		return dialect == DriverClickhouse
+	}
+	return false
+}
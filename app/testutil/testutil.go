@@ -0,0 +1,76 @@
+// Package testutil Testing custom templates with schema snapshots and golden files, no database connection is required.
+//
+// Generate a schema snapshot: pts snapshot -c config.yaml > testdata/schema.json
+//
+// Update golden files: go test ./... -update
+package testutil
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cd365/pts/app"
+)
+
+var update = flag.Bool("update", false, "Update golden files")
+
+// LoadSnapshot Load the schema snapshot output by the snapshot command.
+func LoadSnapshot(t testing.TB, snapshotFile string) *app.Template {
+	t.Helper()
+	content, err := os.ReadFile(snapshotFile)
+	if err != nil {
+		t.Fatalf("read snapshot %s: %v", snapshotFile, err)
+	}
+	tmp, err := app.ParseSnapshot(content)
+	if err != nil {
+		t.Fatalf("parse snapshot %s: %v", snapshotFile, err)
+	}
+	return tmp
+}
+
+// Render Render the template file with the schema snapshot, cfg can be nil.
+func Render(t testing.TB, cfg *app.Config, templateFile string, tmp *app.Template) []byte {
+	t.Helper()
+	content, err := os.ReadFile(templateFile)
+	if err != nil {
+		t.Fatalf("read template %s: %v", templateFile, err)
+	}
+	if cfg == nil {
+		cfg = &app.Config{}
+	}
+	output, err := app.Render(cfg, filepath.Base(templateFile), content, tmp)
+	if err != nil {
+		t.Fatalf("render template %s: %v", templateFile, err)
+	}
+	return output
+}
+
+// Golden Compare the output with the golden file, the golden file is overwritten when the -update flag is set.
+func Golden(t testing.TB, goldenFile string, output []byte) {
+	t.Helper()
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(goldenFile), 0o755); err != nil {
+			t.Fatalf("create golden directory %s: %v", goldenFile, err)
+		}
+		if err := os.WriteFile(goldenFile, output, 0o644); err != nil {
+			t.Fatalf("update golden %s: %v", goldenFile, err)
+		}
+		return
+	}
+	expected, err := os.ReadFile(goldenFile)
+	if err != nil {
+		t.Fatalf("read golden %s: %v", goldenFile, err)
+	}
+	if !bytes.Equal(expected, output) {
+		t.Errorf("output does not match golden %s, run with -update to regenerate\n--- expected\n%s\n--- actual\n%s", goldenFile, expected, output)
+	}
+}
+
+// RenderGolden Render the template file with the schema snapshot and compare the output with the golden file.
+func RenderGolden(t testing.TB, snapshotFile string, templateFile string, goldenFile string) {
+	t.Helper()
+	Golden(t, goldenFile, Render(t, nil, templateFile, LoadSnapshot(t, snapshotFile)))
+}
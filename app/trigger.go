@@ -0,0 +1,62 @@
+package app
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/cd365/hey/v7"
+	"github.com/cd365/hey/v7/cst"
+)
+
+// UpdatedAtTrigger PostgreSQL BEFORE UPDATE trigger that sets the updated_at column to the current timestamp.
+type UpdatedAtTrigger struct {
+	Column   string `yaml:"column" json:"column"`     // timestamp column set by the trigger, such as updated_at; empty disables the trigger
+	Function string `yaml:"function" json:"function"` // trigger function name, default set_updated_at
+}
+
+// updatedAtTriggers Mark the tables that have the updated_at column but no trigger calling the trigger function.
+// nil is returned if the trigger is not configured, the database is not PostgreSQL or no table is missing the trigger.
+func updatedAtTriggers(ctx context.Context, cfg *Config, way *hey.Way, tables []*Table) (*UpdatedAtTrigger, error) {
+	if cfg.UpdatedAtTrigger.Column == "" || way.Config().Manual.DatabaseType != cst.Postgresql {
+		return nil, nil
+	}
+	trigger := cfg.UpdatedAtTrigger
+	if trigger.Function == "" {
+		trigger.Function = "set_updated_at"
+	}
+	// Tables with a trigger calling the function, key is schema.table
+	exists := make(map[string]*struct{})
+	prepare := "SELECT n.nspname, c.relname FROM pg_trigger t INNER JOIN pg_class c ON c.oid = t.tgrelid INNER JOIN pg_namespace n ON n.oid = c.relnamespace INNER JOIN pg_proc p ON p.oid = t.tgfoid WHERE ( NOT t.tgisinternal AND p.proname = ? )"
+	err := way.Query(ctx, hey.NewSQL(prepare, trigger.Function), func(rows *sql.Rows) error {
+		for rows.Next() {
+			schema, table := "", ""
+			if err := rows.Scan(&schema, &table); err != nil {
+				return err
+			}
+			exists[schema+"."+table] = nil
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, err
+	}
+	missing := false
+	for _, table := range tables {
+		if _, ok := exists[table.Database+"."+table.Table]; ok {
+			continue
+		}
+		for _, column := range table.Columns {
+			// The current timestamp can only be assigned to the date and time columns
+			if column.Column == trigger.Column && (strings.HasPrefix(column.dataType(), "timestamp") || column.dataType() == "date") {
+				table.UpdatedAtTriggerMissing = true
+				missing = true
+				break
+			}
+		}
+	}
+	if !missing {
+		return nil, nil
+	}
+	return &trigger, nil
+}
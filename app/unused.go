@@ -0,0 +1,79 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+)
+
+// unusedConfig Configuration entries that match no table or column of the database, such as the misspelled table names.
+// lists are the tables of the database, tables are the exported tables with the columns.
+func unusedConfig(cfg *Config, lists []*Table, tables []*Table) []string {
+	if cfg.Offline {
+		// The tables of the offline mode are the only_table tables
+		return nil
+	}
+	exists := make(map[string]*Table, len(lists))
+	for _, table := range lists {
+		exists[tableKey(cfg, table.Table)] = table
+	}
+	result := make([]string, 0)
+	for _, name := range cfg.OnlyTable {
+		if _, ok := exists[tableKey(cfg, name)]; !ok {
+			result = append(result, fmt.Sprintf("only_table: table %s does not exist", name))
+		}
+	}
+	// The tables of the database are the only_table tables, disable_table is not used
+	if len(cfg.OnlyTable) == 0 {
+		for _, name := range cfg.DisableTable {
+			name = strings.TrimSpace(name)
+			matched := false
+			if strings.HasPrefix(name, "^") && strings.HasSuffix(name, "$") {
+				v := tableRegexp(cfg, name)
+				matched = slices.ContainsFunc(lists, func(t *Table) bool { return v.MatchString(t.Table) })
+			} else {
+				_, matched = exists[tableKey(cfg, name)]
+			}
+			if !matched {
+				result = append(result, fmt.Sprintf("disable_table: %s matches no table", name))
+			}
+		}
+	}
+	exported := make(map[string]*Table, len(tables))
+	for _, table := range tables {
+		exported[tableKey(cfg, table.Table)] = table
+	}
+	names := make([]string, 0, len(cfg.Comments))
+	for name := range cfg.Comments {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		table, ok := exported[name]
+		if !ok {
+			if _, ok = exists[name]; !ok && !queryOnlyTable(cfg) {
+				result = append(result, fmt.Sprintf("comments: table %s does not exist", name))
+			}
+			continue
+		}
+		columns := make([]string, 0, len(cfg.Comments[name].Columns))
+		for column := range cfg.Comments[name].Columns {
+			if !slices.ContainsFunc(table.Columns, func(c *Column) bool { return c.Column == column }) {
+				columns = append(columns, column)
+			}
+		}
+		slices.Sort(columns)
+		for _, column := range columns {
+			result = append(result, fmt.Sprintf("comments: column %s.%s does not exist", name, column))
+		}
+	}
+	return result
+}
+
+// warnUnusedConfig Print the configuration entries that match no table or column to the standard error.
+func warnUnusedConfig(cfg *Config, lists []*Table, tables []*Table) {
+	for _, line := range unusedConfig(cfg, lists, tables) {
+		_, _ = fmt.Fprintf(os.Stderr, "warning: %s\n", line)
+	}
+}
@@ -0,0 +1,265 @@
+package app
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// upDatabase The container settings of a database image.
+type upDatabase struct {
+	driver string   // database driver
+	port   string   // container port
+	env    []string // container environment variables
+	url    string   // connection URI format, %s is the host and port of the published container port
+}
+
+// upDatabases Supported database images, matched by the image name.
+var upDatabases = map[string]*upDatabase{
+	"postgres": {
+		driver: "postgres",
+		port:   "5432/tcp",
+		env:    []string{"POSTGRES_PASSWORD=pts", "POSTGRES_DB=pts"},
+		url:    "postgres://postgres:pts@%s/pts?sslmode=disable",
+	},
+	"mysql": {
+		driver: "mysql",
+		port:   "3306/tcp",
+		env:    []string{"MYSQL_ROOT_PASSWORD=pts", "MYSQL_DATABASE=pts"},
+		url:    "mysql://root:pts@%s/pts?multiStatements=true",
+	},
+	"mariadb": {
+		driver: "mysql",
+		port:   "3306/tcp",
+		env:    []string{"MARIADB_ROOT_PASSWORD=pts", "MARIADB_DATABASE=pts"},
+		url:    "mysql://root:pts@%s/pts?multiStatements=true",
+	},
+}
+
+// upDatabaseOfImage The container settings of the image, such as postgres:16, mysql:8.4, docker.io/library/mariadb:11.
+func upDatabaseOfImage(image string) (*upDatabase, error) {
+	name := image[strings.LastIndex(image, "/")+1:]
+	name, _, _ = strings.Cut(name, ":")
+	name, _, _ = strings.Cut(name, "@")
+	database, ok := upDatabases[name]
+	if !ok {
+		return nil, fmt.Errorf("unsupported database image: %s", image)
+	}
+	return database, nil
+}
+
+// docker Run the docker command, the output is returned without the trailing newline.
+func docker(ctx context.Context, args ...string) (string, error) {
+	output, err := exec.CommandContext(ctx, "docker", args...).Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+			return "", fmt.Errorf("docker %s: %s", args[0], strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return "", fmt.Errorf("docker %s: %w", args[0], err)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// Up Start a disposable database container, apply the migrations, run the configured jobs against it and remove the container.
+// The outputs of the jobs are bundled into the archive with a manifest instead of being written to the files if the archive is set.
+func Up(ctx context.Context, config string, image string, migrations string, archive string) (err error) {
+	cfg, err := ParseConfig(config)
+	if err != nil {
+		return
+	}
+	if len(cfg.Jobs) == 0 {
+		return fmt.Errorf("no jobs are configured in %s", config)
+	}
+	database, err := upDatabaseOfImage(image)
+	if err != nil {
+		return
+	}
+	var bundle *Archive
+	if archive != "" {
+		if bundle, err = NewArchive(archive); err != nil {
+			return
+		}
+	}
+
+	args := []string{"run", "--detach", "--rm", "--publish", "127.0.0.1::" + database.port}
+	for _, v := range database.env {
+		args = append(args, "--env", v)
+	}
+	container, err := docker(ctx, append(args, image)...)
+	if err != nil {
+		return
+	}
+	defer func() {
+		if _, e := docker(context.Background(), "rm", "--force", container); e != nil && err == nil {
+			err = e
+		}
+	}()
+	address, err := docker(ctx, "port", container, database.port)
+	if err != nil {
+		return
+	}
+	// The port may be published on several addresses, one per line
+	address, _, _ = strings.Cut(address, "\n")
+	if _, _, err = net.SplitHostPort(address); err != nil {
+		return fmt.Errorf("docker port: %w", err)
+	}
+	databaseUrl := fmt.Sprintf(database.url, address)
+
+	if err = upMigrate(ctx, databaseUrl, migrations); err != nil {
+		return
+	}
+	for _, job := range cfg.Jobs {
+		options := []Option{WithCustomName(job.Name)}
+		if strings.HasSuffix(job.Output, ".go") {
+			options = append(options, WithValidateGo())
+		}
+		if !job.Group {
+			if err = upJob(ctx, config, databaseUrl, job.Command, job.Output, job.Header, bundle, options...); err != nil {
+				return fmt.Errorf("job %s %s: %w", job.Command, job.Output, err)
+			}
+			continue
+		}
+		for _, group := range cfg.Groups {
+			if err = upGroupJob(ctx, cfg, config, databaseUrl, job.Command, job.Output, job.Header, group, bundle, options...); err != nil {
+				return fmt.Errorf("job %s %s group %s: %w", job.Command, job.Output, group.Name, err)
+			}
+		}
+	}
+	if bundle != nil {
+		err = bundle.Write()
+	}
+	return
+}
+
+// upGroupJob Run the command against the tables of the group and write the output to the directory of the group with the package clause of the group.
+func upGroupJob(ctx context.Context, cfg *Config, config string, databaseUrl string, command string, output string, header string, group *TableGroup, archive *Archive, options ...Option) error {
+	if err := group.validate(); err != nil {
+		return err
+	}
+	if output == "" {
+		return fmt.Errorf("the output file name is not set")
+	}
+	if archive != nil {
+		file, content := groupDoc(cfg, group)
+		archive.Add(file, content, command, group.Name)
+	} else if err := writeGroupDoc(cfg, group); err != nil {
+		return err
+	}
+	packageClause := fmt.Sprintf("package %s\n", group.packageName())
+	if header != "" {
+		header = packageClause + header
+	} else {
+		header = packageClause
+	}
+	return upJob(ctx, config, databaseUrl, command, filepath.Join(group.Dir, output), header, archive, append(options, WithGroup(group.Name))...)
+}
+
+// upMigrate Wait for the database to accept connections and execute the .sql files of the migrations directory in the order of the file names.
+// The .down.sql files of golang-migrate and the -- +goose Down sections of goose are not executed.
+func upMigrate(ctx context.Context, databaseUrl string, migrations string) error {
+	cfg := &Config{}
+	cfg.Database.Url = databaseUrl
+	if err := applyDatabaseUrl(cfg); err != nil {
+		return err
+	}
+	db, err := sql.Open(cfg.Database.Driver, cfg.Database.DataSourceName)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = db.Close() }()
+	// The database image restarts the server after the initialization, it only listens on TCP when it is ready
+	deadline := time.Now().Add(time.Minute * 2)
+	for {
+		if err = db.PingContext(ctx); err == nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("database is not ready: %w", err)
+		}
+		time.Sleep(time.Second)
+	}
+	if migrations == "" {
+		return nil
+	}
+	files, err := filepath.Glob(filepath.Join(migrations, "*.sql"))
+	if err != nil {
+		return err
+	}
+	slices.Sort(files)
+	for _, file := range files {
+		// The down migrations of golang-migrate revert the up migrations
+		if strings.HasSuffix(file, ".down.sql") {
+			continue
+		}
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		if _, err = db.ExecContext(ctx, upMigration(string(content))); err != nil {
+			return fmt.Errorf("migration %s: %w", file, err)
+		}
+	}
+	return nil
+}
+
+// upMigration The statements of the up migration: the -- +goose Up section of a goose migration, the whole content otherwise.
+func upMigration(content string) string {
+	up := strings.Index(content, gooseUp)
+	if up < 0 {
+		return content
+	}
+	content = content[up+len(gooseUp):]
+	if down := strings.Index(content, gooseDown); down >= 0 {
+		content = content[:down]
+	}
+	return content
+}
+
+// upJob Run the command against the database and write the header and the output to the output file, or add them to the archive if it is not nil.
+func upJob(ctx context.Context, config string, databaseUrl string, command string, output string, header string, archive *Archive, options ...Option) error {
+	options = append([]Option{WithDatabaseUrl(databaseUrl), func(cfg *Config) {
+		// The container is the only database, the connection settings of the configuration are not used
+		cfg.Database.Replica = ""
+		cfg.Database.DataSourceName = ""
+		cfg.Database.Auth.Type = ""
+	}}, options...)
+	app, err := NewApp(config, options...)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = app.Close() }()
+	content, err := app.Run(ctx, app.NewOutput(command))
+	if err != nil {
+		return err
+	}
+	if header != "" {
+		// The header is formatted with the output, such as the crlf line endings
+		content = formatOutput(app.cfg, append([]byte(header+"\n"), content...))
+	}
+	if output != "" && archive != nil {
+		archive.Add(output, content, command, app.cfg.groupName)
+		return nil
+	}
+	return writeJobOutput(output, content)
+}
+
+// writeJobOutput Write the content to the output file of the job, the standard output if the output is empty.
+func writeJobOutput(output string, content []byte) error {
+	if output == "" {
+		_, err := os.Stdout.Write(content)
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(output), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(output, content, 0o644)
+}
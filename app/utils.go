@@ -58,6 +58,40 @@ func Underline(str string) string {
 	return *(*string)(unsafe.Pointer(&tmp))
 }
 
+// Kebab Name kebab case.
+func Kebab(str string) string {
+	return strings.ReplaceAll(Underline(str), "_", "-")
+}
+
+// Title Name title case, words are separated by spaces.
+func Title(str string) string {
+	if str == "" {
+		return ""
+	}
+	words := strings.FieldsFunc(Underline(str), func(r rune) bool {
+		return r == '_' || r == '-' || r == ' '
+	})
+	for i, word := range words {
+		words[i] = Pascal(word)
+	}
+	return strings.Join(words, " ")
+}
+
+// Abbrev Name abbreviation, the lowercase first letter of each word.
+func Abbrev(str string) string {
+	if str == "" {
+		return ""
+	}
+	words := strings.FieldsFunc(Underline(str), func(r rune) bool {
+		return r == '_' || r == '-' || r == ' '
+	})
+	tmp := make([]byte, 0, len(words))
+	for _, word := range words {
+		tmp = append(tmp, word[0])
+	}
+	return string(tmp)
+}
+
 func Upper(str string) string {
 	return strings.ToUpper(str)
 }
@@ -0,0 +1,156 @@
+package app
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/parser"
+	"go/scanner"
+	"go/token"
+	"strings"
+	"text/template"
+	"text/template/parse"
+)
+
+// goCommands Commands whose output is go source, validated if validate_go is enabled.
+var goCommands = map[string]*struct{}{
+	CmdReplace: nil,
+	CmdSchema:  nil,
+	CmdTable:   nil,
+	CmdTest:    nil,
+	CmdHey:     nil,
+}
+
+// WithValidateGo Parse the output as go source, such as the output of an up job written to a .go file.
+func WithValidateGo() Option {
+	return func(cfg *Config) {
+		cfg.validateGo = true
+	}
+}
+
+// shouldValidateGo Whether the output of the command is parsed as go source.
+func shouldValidateGo(cfg *Config, cmd string) bool {
+	if cfg.validateGo {
+		return true
+	}
+	_, ok := goCommands[cmd]
+	return ok && cfg.ValidateGo
+}
+
+// hasPackageClause Whether the first token of the go source is the package keyword.
+func hasPackageClause(source []byte) bool {
+	fset := token.NewFileSet()
+	file := fset.AddFile("", fset.Base(), len(source))
+	s := &scanner.Scanner{}
+	s.Init(file, source, nil, 0)
+	_, tok, _ := s.Scan()
+	return tok == token.PACKAGE
+}
+
+// validateGo Parse the output as go source, the output is usually written after a package clause so one is added if missing.
+// A syntax error reports the output line and the template line of the template text nearest before it.
+func validateGo(tt *template.Template, source []byte, output []byte) error {
+	content, offset := output, 0
+	if !hasPackageClause(output) {
+		content, offset = append([]byte("package pts\n"), output...), 1
+	}
+	_, err := parser.ParseFile(token.NewFileSet(), "", content, parser.SkipObjectResolution)
+	if err == nil {
+		return nil
+	}
+	var list scanner.ErrorList
+	if !errors.As(err, &list) || len(list) == 0 {
+		return err
+	}
+	line := list[0].Pos.Line - offset
+	lines := bytes.Split(output, []byte("\n"))
+	text := ""
+	if line > 0 && line <= len(lines) {
+		text = strings.TrimSpace(string(lines[line-1]))
+	}
+	at := ""
+	if n := templateLine(tt, source, output, line); n > 0 {
+		at = fmt.Sprintf(" (template %s line %d)", tt.Name(), n)
+	}
+	return fmt.Errorf("invalid go output at line %d%s: %s\n\t%s", line, at, list[0].Msg, text)
+}
+
+// templateLine The template line that produced the output line, found by the template text nearest before the end of the output line; 0 if not found.
+// Short template texts such as a newline or a comma are ignored, they are found almost everywhere in the output.
+func templateLine(tt *template.Template, source []byte, output []byte, line int) int {
+	if line < 1 {
+		return 0
+	}
+	start := 0
+	for i := 1; i < line && start < len(output); i++ {
+		next := bytes.IndexByte(output[start:], '\n')
+		if next < 0 {
+			return 0
+		}
+		start += next + 1
+	}
+	end := len(output)
+	if next := bytes.IndexByte(output[start:], '\n'); next >= 0 {
+		end = start + next
+	}
+	var nodes []*parse.TextNode
+	for _, t := range tt.Templates() {
+		if t.Tree != nil && t.Tree.Root != nil {
+			nodes = appendTextNodes(nodes, t.Tree.Root)
+		}
+	}
+	var nearest *parse.TextNode
+	nearestStart, nearestEnd := -1, -1
+	for _, node := range nodes {
+		if len(bytes.TrimSpace(node.Text)) < 3 {
+			continue
+		}
+		index := bytes.LastIndex(output[:end], node.Text)
+		if index < 0 {
+			continue
+		}
+		if stop := index + len(node.Text); stop > nearestEnd || (stop == nearestEnd && len(node.Text) > len(nearest.Text)) {
+			nearest, nearestStart, nearestEnd = node, index, stop
+		}
+	}
+	if nearest == nil || int(nearest.Pos) > len(source) {
+		return 0
+	}
+	result := 1 + bytes.Count(source[:nearest.Pos], []byte("\n"))
+	if nearestStart < start {
+		// The output line is inside the template text, or after it and the last line of the text is used
+		result += bytes.Count(output[nearestStart:min(start, nearestEnd)], []byte("\n"))
+	}
+	return result
+}
+
+// appendTextNodes Append the text nodes of the node and its branches.
+func appendTextNodes(nodes []*parse.TextNode, node parse.Node) []*parse.TextNode {
+	switch v := node.(type) {
+	case *parse.TextNode:
+		nodes = append(nodes, v)
+	case *parse.ListNode:
+		if v == nil {
+			return nodes
+		}
+		for _, n := range v.Nodes {
+			nodes = appendTextNodes(nodes, n)
+		}
+	case *parse.IfNode:
+		nodes = appendBranchTextNodes(nodes, &v.BranchNode)
+	case *parse.RangeNode:
+		nodes = appendBranchTextNodes(nodes, &v.BranchNode)
+	case *parse.WithNode:
+		nodes = appendBranchTextNodes(nodes, &v.BranchNode)
+	}
+	return nodes
+}
+
+// appendBranchTextNodes Append the text nodes of both branches.
+func appendBranchTextNodes(nodes []*parse.TextNode, node *parse.BranchNode) []*parse.TextNode {
+	nodes = appendTextNodes(nodes, node.List)
+	if node.ElseList != nil {
+		nodes = appendTextNodes(nodes, node.ElseList)
+	}
+	return nodes
+}
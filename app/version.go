@@ -0,0 +1,170 @@
+package app
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"strings"
+
+	"github.com/cd365/hey/v7"
+	"github.com/cd365/hey/v7/cst"
+)
+
+// Capabilities Features of the database server, decided by the dialect and the server version; the latest server is assumed if the version is unknown.
+type Capabilities struct {
+	GeneratedColumns bool `json:"generated_columns,omitempty"` // generated (computed, virtual) columns: MySQL 5.7, MariaDB 10.2, PostgreSQL 12, SQLite 3.31, Oracle 11
+	Comments         bool `json:"comments,omitempty"`          // table and column comments, COMMENT ON or the COMMENT clause; not SQLite
+	Identity         bool `json:"identity,omitempty"`          // identity columns: PostgreSQL 10, Oracle 12, Redshift and Snowflake; MySQL has AUTO_INCREMENT instead
+	Returning        bool `json:"returning,omitempty"`         // INSERT ... RETURNING: PostgreSQL, MariaDB 10.5, SQLite 3.35, CockroachDB, DuckDB
+	Sequences        bool `json:"sequences,omitempty"`         // CREATE SEQUENCE: PostgreSQL, MariaDB 10.3, Oracle, Snowflake, CockroachDB, DuckDB
+}
+
+// queryServerVersion The statement querying the version of the database server, empty if the version is not queried.
+func queryServerVersion(cfg *Config, way *hey.Way) string {
+	switch way.Config().Manual.DatabaseType {
+	case cst.Mysql:
+		return "SELECT VERSION()"
+	case cst.Postgresql, DriverGreenplum:
+		// Greenplum reports the version of the PostgreSQL it is based on
+		return "SHOW server_version"
+	case DriverCockroach:
+		// CockroachDB CCL v23.2.4 (x86_64-pc-linux-gnu, ...)
+		return "SELECT version()"
+	case DriverRedshift:
+		// PostgreSQL 8.0.2 on i686-pc-linux-gnu, ..., Redshift 1.0.12103
+		return "SELECT version()"
+	case cst.Sqlite:
+		return "SELECT sqlite_version()"
+	case DriverSnowflake:
+		return "SELECT CURRENT_VERSION()"
+	case DriverClickhouse:
+		return "SELECT version()"
+	case DriverDuckdb:
+		// v1.1.3
+		return "SELECT version()"
+	case DriverOracle:
+		return "SELECT MAX(version) FROM product_component_version WHERE product LIKE 'Oracle Database%'"
+	case DriverDb2:
+		// DB2 v11.5.8.0 => 11.5.8.0
+		return "SELECT REPLACE(service_level, 'DB2 v', '') FROM sysibmadm.env_inst_info"
+	case DriverHana:
+		// 2.00.070.00.1679989823
+		return "SELECT version FROM sys.m_database"
+	case DriverFirebird:
+		// 3.0.10
+		return "SELECT rdb$get_context('SYSTEM', 'ENGINE_VERSION') FROM rdb$database"
+	case DriverGeneric:
+		return cfg.Database.Generic.QueryVersion
+	}
+	return ""
+}
+
+// initServer Query the version of the database server and decide the capabilities, the dialect implementations branch on cfg.capabilities.
+// No rows are returned in offline mode, the version is empty then.
+func initServer(ctx context.Context, cfg *Config, way *hey.Way) error {
+	version := sql.NullString{}
+	if prepare := queryServerVersion(cfg, way); prepare != "" {
+		err := way.Query(ctx, hey.NewSQL(prepare), func(rows *sql.Rows) error {
+			for rows.Next() {
+				if err := rows.Scan(&version); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	cfg.serverVersion = strings.TrimSpace(version.String)
+	dialect := string(way.Config().Manual.DatabaseType)
+	cfg.mariadb = dialect == string(cst.Mysql) && (cfg.Database.Driver == DriverMariadb || strings.Contains(strings.ToLower(cfg.serverVersion), "mariadb"))
+	if cfg.mariadb {
+		dialect = DriverMariadb
+	}
+	cfg.capabilities = serverCapabilities(dialect, cfg.serverVersion)
+	return nil
+}
+
+// versionNumbers The numbers of the first version in the text, 8.0.35-0ubuntu0.22.04.1 => 8 0 35, PostgreSQL 8.0.2 on ... => 8 0 2; nil if there is no number.
+func versionNumbers(version string) []int {
+	start := strings.IndexAny(version, "0123456789")
+	if start < 0 {
+		return nil
+	}
+	numbers := make([]int, 0, 3)
+	for _, part := range strings.Split(version[start:], ".") {
+		end := 0
+		for end < len(part) && part[end] >= '0' && part[end] <= '9' {
+			end++
+		}
+		if end == 0 {
+			break
+		}
+		number, err := strconv.Atoi(part[:end])
+		if err != nil {
+			break
+		}
+		numbers = append(numbers, number)
+		if end < len(part) {
+			break
+		}
+	}
+	return numbers
+}
+
+// serverCapabilities The capabilities of the dialect at the server version, nothing is assumed of the generic driver.
+func serverCapabilities(dialect string, version string) Capabilities {
+	numbers := versionNumbers(version)
+	atLeast := func(major int, minor int) bool {
+		if len(numbers) == 0 {
+			return true
+		}
+		if numbers[0] != major || len(numbers) == 1 {
+			return numbers[0] > major || numbers[0] == major && minor == 0
+		}
+		return numbers[1] >= minor
+	}
+	switch dialect {
+	case string(cst.Mysql), DriverMariadb:
+		if dialect == DriverMariadb || strings.Contains(strings.ToLower(version), "mariadb") {
+			return Capabilities{GeneratedColumns: atLeast(10, 2), Comments: true, Returning: atLeast(10, 5), Sequences: atLeast(10, 3)}
+		}
+		return Capabilities{GeneratedColumns: atLeast(5, 7), Comments: true}
+	case string(cst.Postgresql), DriverGreenplum:
+		return Capabilities{GeneratedColumns: atLeast(12, 0), Comments: true, Identity: atLeast(10, 0), Returning: true, Sequences: true}
+	case DriverRedshift:
+		return Capabilities{Comments: true, Identity: true}
+	case DriverBigquery:
+		// Table and column descriptions
+		return Capabilities{Comments: true}
+	case DriverSpanner:
+		// THEN RETURN, bit-reversed sequences and IDENTITY columns; there are no comments
+		return Capabilities{GeneratedColumns: true, Identity: true, Returning: true, Sequences: true}
+	case DriverSnowflake:
+		return Capabilities{Comments: true, Identity: true, Sequences: true}
+	case DriverCockroach:
+		// Stored computed columns since 2.0, identity columns since 21.2
+		return Capabilities{GeneratedColumns: true, Comments: true, Identity: atLeast(21, 2), Returning: true, Sequences: true}
+	case string(cst.Sqlite):
+		return Capabilities{GeneratedColumns: atLeast(3, 31), Returning: atLeast(3, 35)}
+	case DriverOracle:
+		return Capabilities{GeneratedColumns: atLeast(11, 0), Comments: true, Identity: atLeast(12, 0), Sequences: true}
+	case DriverDb2:
+		// SELECT FROM FINAL TABLE instead of RETURNING
+		return Capabilities{GeneratedColumns: true, Comments: true, Identity: true, Sequences: true}
+	case DriverHana:
+		// Calculated columns, SELECT ... FROM FINAL TABLE is not supported
+		return Capabilities{GeneratedColumns: true, Comments: true, Identity: true, Sequences: true}
+	case DriverFirebird:
+		// COMPUTED BY columns, generators; identity columns since 3.0
+		return Capabilities{GeneratedColumns: true, Comments: true, Identity: atLeast(3, 0), Returning: true, Sequences: true}
+	case DriverClickhouse:
+		// MATERIALIZED and ALIAS columns
+		return Capabilities{GeneratedColumns: true, Comments: true}
+	case DriverDuckdb:
+		// Virtual generated columns since 0.8, COMMENT ON since 0.10; sequences instead of identity columns
+		return Capabilities{GeneratedColumns: atLeast(0, 8), Comments: atLeast(0, 10), Returning: true, Sequences: true}
+	}
+	return Capabilities{}
+}